@@ -1,27 +1,122 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/config"
+	"github.com/suhriar/blog-mono-api/internal/delivery/middleware"
 	"github.com/suhriar/blog-mono-api/internal/delivery/rest"
 	repository "github.com/suhriar/blog-mono-api/internal/repository/mysql"
 	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/internal/worker"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/trending"
+	"github.com/suhriar/blog-mono-api/pkg/viewcount"
 )
 
-func NewApp(router *mux.Router, db *sql.DB) {
+// NewApp wires the application into the router and starts the background
+// workers. The returned shutdown func writes the state buffered in memory and
+// must be called once the server stopped, before the database is closed.
+func NewApp(ctx context.Context, router *mux.Router, db *sql.DB) (shutdown func()) {
 	// init repo
 	userRepo := repository.NewUserRepository(db)
 	postRepo := repository.NewPostRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	filterRepo := repository.NewFilterRepository(db)
+	bookmarkRepo := repository.NewBookmarkRepository(db)
+	trendingRepo := repository.NewTrendingRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	blockRepo := repository.NewBlockRepository(db)
+
+	// init content filters
+	classifier := filter.NewBayes(filterRepo)
+	if err := classifier.Load(ctx); err != nil {
+		log.Error().Err(err).Msg("error load spam classifier, starting untrained")
+	}
+	contentPolicy := filter.Policy{
+		Filter: filter.Chain{
+			filter.NewBannedWords(config.AppConfig.Filter.BannedWords, 0.5),
+			filter.LinkLimit{Max: config.AppConfig.Filter.MaxLinks},
+			filter.NewDuplicate(filterRepo, config.AppConfig.Filter.DuplicateWindow, config.AppConfig.Filter.DuplicateScore),
+			classifier,
+		},
+		ModerateScore: config.AppConfig.Filter.ModerateScore,
+		RejectScore:   config.AppConfig.Filter.RejectScore,
+	}
+
+	mail := newMailer(config.AppConfig.Mail)
+
+	viewCounter := viewcount.NewCounter(postRepo, config.AppConfig.View.DedupWindow)
 
 	// init usecase
-	userUsecase := usecase.NewUserUsecase(userRepo)
-	postUsecase := usecase.NewPostUsecase(postRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, mail, config.AppConfig.Password.ResetTTL, config.AppConfig.Password.ResetURL,
+		config.AppConfig.Verify.TTL, config.AppConfig.Verify.URL, config.AppConfig.Verify.ResendInterval, model.UnverifiedAccess(config.AppConfig.Verify.UnverifiedAccess),
+		model.AccountDeletionPolicy(config.AppConfig.Account.DeletionPolicy))
+	postUsecase := usecase.NewPostUsecase(postRepo, config.AppConfig.Comment.MaxDepth, config.AppConfig.Comment.EmbedLimit, config.AppConfig.Comment.ReplyLimit, contentPolicy, classifier, config.AppConfig.Reaction.Types, viewCounter,
+		config.AppConfig.Filter.ModeratorIDs)
+	searchUsecase := usecase.NewSearchUsecase(searchRepo)
+	bookmarkUsecase := usecase.NewBookmarkUsecase(bookmarkRepo, postRepo)
+	trendingWeights := trending.Weights{
+		model.EngagementLike:    config.AppConfig.Trending.LikeWeight,
+		model.EngagementComment: config.AppConfig.Trending.CommentWeight,
+		model.EngagementView:    config.AppConfig.Trending.ViewWeight,
+	}
+	followUsecase := usecase.NewFollowUsecase(followRepo, userRepo)
+	blockUsecase := usecase.NewBlockUsecase(blockRepo, userRepo)
+	trendingUsecase := usecase.NewTrendingUsecase(trendingRepo, trendingWeights, config.AppConfig.Trending.HalfLife, config.AppConfig.Trending.Window, config.AppConfig.Trending.Size)
 
 	// init handler
 	userHandler := rest.NewUserHandler(userUsecase)
 	postHandler := rest.NewPostHandler(postUsecase)
+	searchHandler := rest.NewSearchHandler(searchUsecase)
+	bookmarkHandler := rest.NewBookmarkHandler(bookmarkUsecase)
+	trendingHandler := rest.NewTrendingHandler(trendingUsecase)
+	followHandler := rest.NewFollowHandler(followUsecase)
+	blockHandler := rest.NewBlockHandler(blockUsecase)
 
 	// regis rest
-	rest.RegisterRoutes(router, userHandler, postHandler)
+	jwtMiddleware := middleware.NewJWTMiddleware(userRepo)
+	rest.RegisterRoutes(router, jwtMiddleware, userHandler, postHandler, searchHandler, bookmarkHandler, trendingHandler, followHandler, blockHandler)
+
+	// background workers
+	go worker.Run(ctx, "trash-purge", config.AppConfig.Trash.PurgeInterval, func(ctx context.Context) error {
+		return postUsecase.PurgeTrash(ctx, time.Now().Add(-config.AppConfig.Trash.Retention))
+	})
+	go worker.Run(ctx, "post-scheduler", config.AppConfig.Post.SchedulerInterval, func(ctx context.Context) error {
+		return postUsecase.PublishScheduledPosts(ctx, time.Now())
+	})
+	go worker.Run(ctx, "trending", config.AppConfig.Trending.Interval, func(ctx context.Context) error {
+		return trendingUsecase.RefreshTrending(ctx, time.Now())
+	})
+	go worker.Run(ctx, "view-flush", config.AppConfig.View.FlushInterval, viewCounter.Flush)
+
+	return func() {
+		// write the views buffered since the last flush
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := viewCounter.Flush(flushCtx); err != nil {
+			log.Error().Err(err).Msg("error flush buffered views")
+		}
+	}
+}
+
+// newMailer returns the mailer selected by the config, unknown drivers fall
+// back to writing mails to a file
+func newMailer(cfg config.MailConfig) mailer.Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return mailer.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "memory":
+		return mailer.NewMemory()
+	case "file":
+	default:
+		log.Warn().Str("driver", cfg.Driver).Msg("unknown mail driver, writing mails to file")
+	}
+	return mailer.NewFile(cfg.FilePath, cfg.From)
 }
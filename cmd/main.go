@@ -48,7 +48,7 @@ func main() {
 	// Router setup
 	router := mux.NewRouter()
 
-	app.NewApp(router, db)
+	shutdownApp := app.NewApp(ctx, router, db)
 
 	// Start server
 	server := &http.Server{
@@ -79,5 +79,8 @@ func main() {
 		log.Error().Err(err).Msg("Failed to shutdown HTTP server gracefully")
 	}
 
+	// Flush buffered state while the database is still open
+	shutdownApp()
+
 	log.Info().Msg("Server shut down successfully")
 }
@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,10 +14,21 @@ var AppConfig *Config
 
 // Config holds all configuration for the application
 type Config struct {
-	Server ServerConfig
-	MySql  MySqlConfig
-	Jwt    JwtConfig
-	Log    LogConfig
+	Server   ServerConfig
+	MySql    MySqlConfig
+	Jwt      JwtConfig
+	Log      LogConfig
+	Trash    TrashConfig
+	Post     PostConfig
+	Comment  CommentConfig
+	Filter   FilterConfig
+	Reaction ReactionConfig
+	View     ViewConfig
+	Trending TrendingConfig
+	Mail     MailConfig
+	Password PasswordConfig
+	Verify   VerifyConfig
+	Account  AccountConfig
 }
 
 type ServerConfig struct {
@@ -41,6 +54,98 @@ type LogConfig struct {
 	LogFilePath    string
 }
 
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+type PostConfig struct {
+	SchedulerInterval time.Duration
+}
+
+type CommentConfig struct {
+	MaxDepth   int
+	EmbedLimit int
+	ReplyLimit int
+}
+
+// FilterConfig tunes the spam and profanity filters. Content scoring at least
+// RejectScore is rejected, content scoring at least ModerateScore needs approval,
+// from the post author for comments and from one of the ModeratorIDs for posts.
+// DuplicateScore is given to content the user already submitted within the
+// DuplicateWindow, by default it needs approval instead of being rejected.
+type FilterConfig struct {
+	BannedWords     []string
+	MaxLinks        int
+	DuplicateWindow time.Duration
+	DuplicateScore  float64
+	ModerateScore   float64
+	RejectScore     float64
+	ModeratorIDs    []int64
+}
+
+// ReactionConfig lists the reaction types users may give to a post, the like
+// reaction is always accepted
+type ReactionConfig struct {
+	Types []string
+}
+
+// ViewConfig tunes post view counting. Views of the same user or IP address
+// within DedupWindow count once, buffered views are written every FlushInterval.
+type ViewConfig struct {
+	DedupWindow   time.Duration
+	FlushInterval time.Duration
+}
+
+// TrendingConfig tunes the trending rankings, refreshed every Interval from the
+// engagement within Window. Engagement loses half its weight every HalfLife.
+type TrendingConfig struct {
+	Interval      time.Duration
+	Window        time.Duration
+	HalfLife      time.Duration
+	Size          int
+	LikeWeight    float64
+	CommentWeight float64
+	ViewWeight    float64
+}
+
+// MailConfig selects how emails are sent. Driver is smtp to send them through
+// the SMTP server, file to append them to FilePath or memory to keep them in
+// memory.
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	FilePath     string
+}
+
+// PasswordConfig tunes password resets, the reset token is added to ResetURL
+// and expires after ResetTTL
+type PasswordConfig struct {
+	ResetTTL time.Duration
+	ResetURL string
+}
+
+// VerifyConfig tunes email verification, the verification token is added to
+// URL and expires after TTL. A new link is sent at most once every
+// ResendInterval. UnverifiedAccess is full, read or none, see
+// model.UnverifiedAccess.
+type VerifyConfig struct {
+	TTL              time.Duration
+	URL              string
+	ResendInterval   time.Duration
+	UnverifiedAccess string
+}
+
+// AccountConfig decides what happens to the content of deleted accounts,
+// DeletionPolicy is anonymize or delete, see model.AccountDeletionPolicy
+type AccountConfig struct {
+	DeletionPolicy string
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() {
 	// Load .env file if it exists
@@ -70,6 +175,66 @@ func LoadConfig() {
 	}
 
 	AppConfig.Log.LogFileEnabled, _ = strconv.ParseBool(getEnv("LOG_FILE_ENABLED", "true"))
+
+	AppConfig.Trash.Retention = getEnvDuration("TRASH_RETENTION", 30*24*time.Hour)
+	AppConfig.Trash.PurgeInterval = getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour)
+	AppConfig.Post.SchedulerInterval = getEnvDuration("POST_SCHEDULER_INTERVAL", time.Minute)
+	AppConfig.Comment.MaxDepth = getEnvInt("COMMENT_MAX_DEPTH", 5)
+	AppConfig.Comment.EmbedLimit = getEnvInt("COMMENT_EMBED_LIMIT", 20)
+	AppConfig.Comment.ReplyLimit = getEnvInt("COMMENT_REPLY_LIMIT", 10)
+	AppConfig.Filter.BannedWords = getEnvList("FILTER_BANNED_WORDS")
+	AppConfig.Filter.MaxLinks = getEnvInt("FILTER_MAX_LINKS", 3)
+	AppConfig.Filter.DuplicateWindow = getEnvDuration("FILTER_DUPLICATE_WINDOW", 24*time.Hour)
+	AppConfig.Filter.DuplicateScore = getEnvFloat("FILTER_DUPLICATE_SCORE", 0.7)
+	AppConfig.Filter.ModerateScore = getEnvFloat("FILTER_MODERATE_SCORE", 0.5)
+	AppConfig.Filter.RejectScore = getEnvFloat("FILTER_REJECT_SCORE", 0.9)
+	for _, item := range getEnvList("FILTER_MODERATOR_IDS") {
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			log.Printf("Warning: invalid user id %q in FILTER_MODERATOR_IDS, skipping it", item)
+			continue
+		}
+		AppConfig.Filter.ModeratorIDs = append(AppConfig.Filter.ModeratorIDs, id)
+	}
+	AppConfig.Reaction.Types = getEnvList("REACTION_TYPES")
+	if len(AppConfig.Reaction.Types) == 0 {
+		AppConfig.Reaction.Types = []string{"like", "love", "laugh", "insightful", "sad", "angry"}
+	}
+	AppConfig.View.DedupWindow = getEnvDuration("VIEW_DEDUP_WINDOW", 30*time.Minute)
+	AppConfig.View.FlushInterval = getEnvDuration("VIEW_FLUSH_INTERVAL", 10*time.Second)
+	AppConfig.Trending.Interval = getEnvDuration("TRENDING_INTERVAL", 5*time.Minute)
+	AppConfig.Trending.Window = getEnvDuration("TRENDING_WINDOW", 72*time.Hour)
+	AppConfig.Trending.HalfLife = getEnvDuration("TRENDING_HALF_LIFE", 24*time.Hour)
+	AppConfig.Trending.Size = getEnvInt("TRENDING_SIZE", 100)
+	AppConfig.Trending.LikeWeight = getEnvFloat("TRENDING_LIKE_WEIGHT", 1)
+	AppConfig.Trending.CommentWeight = getEnvFloat("TRENDING_COMMENT_WEIGHT", 2)
+	AppConfig.Trending.ViewWeight = getEnvFloat("TRENDING_VIEW_WEIGHT", 0.1)
+	AppConfig.Mail.Driver = getEnv("MAIL_DRIVER", "file")
+	AppConfig.Mail.From = getEnv("MAIL_FROM", "no-reply@localhost")
+	AppConfig.Mail.SMTPHost = getEnv("SMTP_HOST", "localhost")
+	AppConfig.Mail.SMTPPort = getEnv("SMTP_PORT", "587")
+	AppConfig.Mail.SMTPUsername = getEnv("SMTP_USERNAME", "")
+	AppConfig.Mail.SMTPPassword = getEnv("SMTP_PASSWORD", "")
+	AppConfig.Mail.FilePath = getEnv("MAIL_FILE_PATH", "logs/mail.log")
+	AppConfig.Password.ResetTTL = getEnvDuration("PASSWORD_RESET_TTL", time.Hour)
+	AppConfig.Password.ResetURL = getEnv("PASSWORD_RESET_URL", "http://localhost:8080/reset-password")
+	AppConfig.Verify.TTL = getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour)
+	AppConfig.Verify.URL = getEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/api/users/email/verify")
+	AppConfig.Verify.ResendInterval = getEnvDuration("EMAIL_VERIFICATION_RESEND_INTERVAL", 5*time.Minute)
+	AppConfig.Verify.UnverifiedAccess = getEnv("EMAIL_UNVERIFIED_ACCESS", "read")
+	switch AppConfig.Verify.UnverifiedAccess {
+	case "full", "read", "none":
+	default:
+		log.Printf("Warning: invalid EMAIL_UNVERIFIED_ACCESS %q, using default read", AppConfig.Verify.UnverifiedAccess)
+		AppConfig.Verify.UnverifiedAccess = "read"
+	}
+	AppConfig.Account.DeletionPolicy = getEnv("ACCOUNT_DELETION_POLICY", "anonymize")
+	switch AppConfig.Account.DeletionPolicy {
+	case "anonymize", "delete":
+	default:
+		log.Printf("Warning: invalid ACCOUNT_DELETION_POLICY %q, using default anonymize", AppConfig.Account.DeletionPolicy)
+		AppConfig.Account.DeletionPolicy = "anonymize"
+	}
 }
 
 // Helper function to get environment variable with a default value
@@ -79,3 +244,59 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// Helper function to get a positive duration environment variable (e.g. "720h") with a default value
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: invalid duration for %s, using default %s", key, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// Helper function to get an integer environment variable with a default value
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid number for %s, using default %d", key, fallback)
+		return fallback
+	}
+	return number
+}
+
+// Helper function to get a float environment variable with a default value
+func getEnvFloat(key string, fallback float64) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Warning: invalid number for %s, using default %g", key, fallback)
+		return fallback
+	}
+	return number
+}
+
+// Helper function to get a comma separated environment variable, empty items are dropped
+func getEnvList(key string) []string {
+	items := []string{}
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -8,23 +8,39 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/rs/zerolog/log"
 	"github.com/suhriar/blog-mono-api/config"
 	"github.com/suhriar/blog-mono-api/model"
 	"github.com/suhriar/blog-mono-api/pkg/utils"
 )
 
+// UserChecker mengecek apakah akun pemilik token masih aktif
+type UserChecker interface {
+	IsUserActive(ctx context.Context, userID int64) (active bool, err error)
+}
+
 type JWTMiddleware struct {
-	secretKey []byte
+	secretKey        []byte
+	unverifiedAccess model.UnverifiedAccess
+	users            UserChecker
 }
 
-func NewJWTMiddleware() *JWTMiddleware {
+func NewJWTMiddleware(users UserChecker) *JWTMiddleware {
 	return &JWTMiddleware{
-		secretKey: []byte(config.AppConfig.Jwt.Secret),
+		secretKey:        []byte(config.AppConfig.Jwt.Secret),
+		unverifiedAccess: model.UnverifiedAccess(config.AppConfig.Verify.UnverifiedAccess),
+		users:            users,
 	}
 }
 
 // Middleware mengecek JWT token untuk endpoint yang terproteksi
 func (m *JWTMiddleware) Middleware(next http.Handler) http.Handler {
+	return m.authenticate(next, true)
+}
+
+// authenticate mengecek JWT token, checkVerified menolak request yang tidak
+// diizinkan untuk user yang emailnya belum terverifikasi
+func (m *JWTMiddleware) authenticate(next http.Handler, checkVerified bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -62,6 +78,23 @@ func (m *JWTMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Token dari akun yang sudah dihapus tidak berlaku lagi
+		active, err := m.users.IsUserActive(r.Context(), claims.UserID)
+		if err != nil {
+			log.Error().Err(err).Msg("error check user from database")
+			utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"message": "Internal server error"})
+			return
+		}
+		if !active {
+			utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"message": "Account no longer exists"})
+			return
+		}
+
+		if checkVerified && !m.allowsUnverified(claims, r) {
+			utils.RespondWithJSON(w, http.StatusForbidden, map[string]string{"message": "Email is not verified"})
+			return
+		}
+
 		// Tambahkan data user ke context
 		ctx := context.WithValue(r.Context(), model.UserNameKey, claims.Username)
 		ctx = context.WithValue(ctx, model.UserEmailKey, claims.Email)
@@ -77,3 +110,25 @@ func (m *JWTMiddleware) Middleware(next http.Handler) http.Handler {
 func (m *JWTMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return m.Middleware(next)
 }
+
+// AllowUnverified memastikan user sudah terautentikasi tanpa mengecek apakah
+// emailnya sudah terverifikasi, untuk endpoint seperti refresh token
+func (m *JWTMiddleware) AllowUnverified(next http.Handler) http.Handler {
+	return m.authenticate(next, false)
+}
+
+// allowsUnverified mengecek apakah request diizinkan untuk token tersebut,
+// hanya token user yang emailnya belum terverifikasi yang dibatasi
+func (m *JWTMiddleware) allowsUnverified(claims *model.JwtCustomClaims, r *http.Request) bool {
+	if claims.EmailVerified {
+		return true
+	}
+
+	switch m.unverifiedAccess {
+	case model.UnverifiedAccessNone:
+		return false
+	case model.UnverifiedAccessRead:
+		return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+	}
+	return true
+}
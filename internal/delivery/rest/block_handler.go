@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+type BlockHandler struct {
+	blockUsecase usecase.BlockUsecase
+}
+
+func NewBlockHandler(blockUsecase usecase.BlockUsecase) *BlockHandler {
+	return &BlockHandler{blockUsecase: blockUsecase}
+}
+
+func (h *BlockHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	restrictUser(w, r, h.blockUsecase.BlockUser, "User blocked")
+}
+
+func (h *BlockHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	restrictUser(w, r, h.blockUsecase.UnblockUser, "User unblocked")
+}
+
+func (h *BlockHandler) MuteUser(w http.ResponseWriter, r *http.Request) {
+	restrictUser(w, r, h.blockUsecase.MuteUser, "User muted")
+}
+
+func (h *BlockHandler) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	restrictUser(w, r, h.blockUsecase.UnmuteUser, "User unmuted")
+}
+
+// GetBlockedUsers lists the users blocked by the current user
+func (h *BlockHandler) GetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	listRestrictedUsers(w, r, h.blockUsecase.GetBlockedUsers)
+}
+
+// GetMutedUsers lists the users muted by the current user
+func (h *BlockHandler) GetMutedUsers(w http.ResponseWriter, r *http.Request) {
+	listRestrictedUsers(w, r, h.blockUsecase.GetMutedUsers)
+}
+
+// restrictUser applies action from the current user to the user of the id in
+// the path and responds with message on success
+func restrictUser(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, targetID, userID int64) error, message string) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = action(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": message})
+}
+
+// listRestrictedUsers parses the paging parameters and responds with the page
+// of the current user returned by list
+func listRestrictedUsers(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID int64, pageSize, pageIndex int) (model.GetRestrictedUsersResponse, error)) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := list(r.Context(), user.ID, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
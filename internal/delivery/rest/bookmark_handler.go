@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+type BookmarkHandler struct {
+	bookmarkUsecase usecase.BookmarkUsecase
+}
+
+func NewBookmarkHandler(bookmarkUsecase usecase.BookmarkUsecase) *BookmarkHandler {
+	return &BookmarkHandler{bookmarkUsecase: bookmarkUsecase}
+}
+
+// BookmarkPost saves the post, the request body naming the collection is optional
+func (h *BookmarkHandler) BookmarkPost(w http.ResponseWriter, r *http.Request) {
+	var request model.BookmarkRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.bookmarkUsecase.BookmarkPost(r.Context(), id, user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Post bookmarked"})
+}
+
+func (h *BookmarkHandler) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.bookmarkUsecase.RemoveBookmark(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Bookmark removed"})
+}
+
+// GetBookmarks lists the bookmarks of the current user, the collection query
+// parameter restricts them to one collection (empty for the default one)
+func (h *BookmarkHandler) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	var collection *string
+	if params.Has("collection") {
+		name := params.Get("collection")
+		collection = &name
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.bookmarkUsecase.GetBookmarks(r.Context(), user.ID, collection, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *BookmarkHandler) GetBookmarkCollections(w http.ResponseWriter, r *http.Request) {
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.bookmarkUsecase.GetBookmarkCollections(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+func (h *PostHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	params := r.URL.Query()
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	threaded, err := commentView(params.Get("comment-view"))
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment-view"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetComments(r.Context(), id, user.ID, model.CommentSort(params.Get("sort")), pageSize, params.Get("cursor"), threaded)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *PostHandler) GetCommentReplies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	commentID, err := strconv.ParseInt(vars["commentId"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment ID"})
+		return
+	}
+
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	threaded, err := commentView(params.Get("comment-view"))
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment-view"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetCommentReplies(r.Context(), id, commentID, user.ID, pageSize, pageIndex, threaded)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *PostHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	var request model.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	commentID, err := strconv.ParseInt(vars["commentId"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	status, err := h.postUsecase.UpdateComment(r.Context(), id, commentID, user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	if status == model.CommentStatusPending {
+		utils.RespondWithJSON(w, http.StatusAccepted, map[string]string{"message": "Comment awaiting approval"})
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Update comment success"})
+}
+
+func (h *PostHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	commentID, err := strconv.ParseInt(vars["commentId"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.postUsecase.DeleteComment(r.Context(), id, commentID, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Delete comment success"})
+}
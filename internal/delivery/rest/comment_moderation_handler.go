@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+func (h *PostHandler) UpdateCommentSettings(w http.ResponseWriter, r *http.Request) {
+	var request model.UpdateCommentSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.postUsecase.UpdateCommentSettings(r.Context(), id, user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Update comment settings success"})
+}
+
+func (h *PostHandler) GetPendingComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetPendingComments(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *PostHandler) ApproveComment(w http.ResponseWriter, r *http.Request) {
+	h.moderateComment(w, r, h.postUsecase.ApproveComment, "Comment approved")
+}
+
+func (h *PostHandler) RejectComment(w http.ResponseWriter, r *http.Request) {
+	h.moderateComment(w, r, h.postUsecase.RejectComment, "Comment rejected")
+}
+
+// moderateComment applies a moderation decision to the comment in the route
+func (h *PostHandler) moderateComment(w http.ResponseWriter, r *http.Request, moderate func(ctx context.Context, postID, commentID, userID int64) error, message string) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	commentID, err := strconv.ParseInt(vars["commentId"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = moderate(r.Context(), id, commentID, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": message})
+}
@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+// respondWithError maps usecase errors to the matching HTTP status code
+func respondWithError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, model.ErrPostNotFound),
+		errors.Is(err, model.ErrCommentNotFound),
+		errors.Is(err, model.ErrRevisionNotFound),
+		errors.Is(err, model.ErrNotInTrash),
+		errors.Is(err, model.ErrBookmarkNotFound),
+		errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, model.ErrFollowNotFound),
+		errors.Is(err, model.ErrBlockNotFound),
+		errors.Is(err, model.ErrMuteNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, model.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, model.ErrContentRejected):
+		status = http.StatusUnprocessableEntity
+	}
+
+	utils.RespondWithJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+type FollowHandler struct {
+	followUsecase usecase.FollowUsecase
+}
+
+func NewFollowHandler(followUsecase usecase.FollowUsecase) *FollowHandler {
+	return &FollowHandler{followUsecase: followUsecase}
+}
+
+func (h *FollowHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.followUsecase.FollowUser(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "User followed"})
+}
+
+func (h *FollowHandler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.followUsecase.UnfollowUser(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "User unfollowed"})
+}
+
+func (h *FollowHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	listFollows(w, r, h.followUsecase.GetFollowers)
+}
+
+func (h *FollowHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	listFollows(w, r, h.followUsecase.GetFollowing)
+}
+
+// GetFollowCounts returns the follower and following counts of the user and
+// whether the current user follows them
+func (h *FollowHandler) GetFollowCounts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.followUsecase.GetFollowCounts(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// listFollows parses the id and paging parameters shared by the follow
+// listings and responds with the page returned by list
+func listFollows(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID int64, pageSize, pageIndex int) (model.GetFollowsResponse, error)) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	res, err := list(r.Context(), id, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+func (h *PostHandler) GetPostLikes(w http.ResponseWriter, r *http.Request) {
+	listLikes(w, r, h.postUsecase.GetPostLikes)
+}
+
+func (h *PostHandler) GetLikedPosts(w http.ResponseWriter, r *http.Request) {
+	listLikes(w, r, h.postUsecase.GetLikedPosts)
+}
+
+// listLikes parses the id and paging parameters shared by the like listings
+// and responds with the page returned by list
+func listLikes[T any](w http.ResponseWriter, r *http.Request, list func(ctx context.Context, id, viewerID int64, pageSize, pageIndex int) (T, error)) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := list(r.Context(), id, user.ID, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
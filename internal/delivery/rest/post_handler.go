@@ -32,12 +32,16 @@ func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.postUsecase.CreatePost(r.Context(), user.ID, request)
+	status, err := h.postUsecase.CreatePost(r.Context(), user.ID, request)
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		respondWithError(w, err)
 		return
 	}
 
+	if status == model.PostStatusPending {
+		utils.RespondWithJSON(w, http.StatusAccepted, map[string]string{"message": "Post awaiting moderation"})
+		return
+	}
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Create new post success"})
 }
 
@@ -50,9 +54,21 @@ func (h *PostHandler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := h.postUsecase.GetPostByID(r.Context(), id)
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	threaded, err := commentView(r.URL.Query().Get("comment-view"))
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comment-view"})
+		return
+	}
+
+	res, err := h.postUsecase.GetPostByID(r.Context(), id, user.ID, clientIP(r), threaded)
+	if err != nil {
+		respondWithError(w, err)
 		return
 	}
 
@@ -60,28 +76,166 @@ func (h *PostHandler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PostHandler) GetAllPost(w http.ResponseWriter, r *http.Request) {
-	pageIndexStr := r.URL.Query().Get("page-index")
-	pageSizeStr := r.URL.Query().Get("page-size")
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
 
-	pageIndex, err := strconv.Atoi(pageIndexStr)
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	from, err := optionalTime(params.Get("from"), false)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid from"})
+		return
+	}
+
+	to, err := optionalTime(params.Get("to"), true)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid to"})
+		return
+	}
+
+	filter := model.PostFilter{
+		Author:  params.Get("author"),
+		Hashtag: params.Get("hashtag"),
+		From:    from,
+		To:      to,
+		Sort:    model.PostSort(params.Get("sort")),
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetAllPost(r.Context(), user.ID, filter, pageSize, pageIndex, params.Get("cursor"))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// GetFeed lists the posts of the authors the current user follows, paged by cursor
+func (h *PostHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetFeed(r.Context(), user.ID, pageSize, params.Get("cursor"))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	var request model.UpdatePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
 		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
 		return
 	}
 
-	pageSize, err := strconv.Atoi(pageSizeStr)
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	status, err := h.postUsecase.UpdatePost(r.Context(), id, user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	if status == model.PostStatusPending {
+		utils.RespondWithJSON(w, http.StatusAccepted, map[string]string{"message": "Post awaiting moderation"})
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Update post success"})
+}
+
+func (h *PostHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
 		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
 		return
 	}
 
-	res, err := h.postUsecase.GetAllPost(r.Context(), pageSize, pageIndex)
+	user, err := utils.GetUserFromContext(r.Context())
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, res)
+	err = h.postUsecase.DeletePost(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Delete post success"})
+}
+
+func (h *PostHandler) UpdatePostStatus(w http.ResponseWriter, r *http.Request) {
+	var request model.UpdatePostStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	vars := mux.Vars(r)
+	idStr := vars["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.postUsecase.UpdatePostStatus(r.Context(), id, user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Update post status success"})
 }
 
 func (h *PostHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
@@ -105,12 +259,16 @@ func (h *PostHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.postUsecase.CreateComment(r.Context(), id, user.ID, request)
+	status, err := h.postUsecase.CreateComment(r.Context(), id, user.ID, request)
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		respondWithError(w, err)
 		return
 	}
 
+	if status == model.CommentStatusPending {
+		utils.RespondWithJSON(w, http.StatusAccepted, map[string]string{"message": "Comment awaiting approval"})
+		return
+	}
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Comment created"})
 }
 
@@ -137,9 +295,9 @@ func (h *PostHandler) UpsertUserActivity(w http.ResponseWriter, r *http.Request)
 
 	err = h.postUsecase.UpsertUserActivity(r.Context(), id, user.ID, request)
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		respondWithError(w, err)
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Like success"})
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Reaction updated"})
 }
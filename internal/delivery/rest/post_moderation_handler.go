@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+// GetPendingPosts lists the posts held for moderation, oldest first
+func (h *PostHandler) GetPendingPosts(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.postUsecase.GetPendingPosts(r.Context(), user.ID, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *PostHandler) ApprovePost(w http.ResponseWriter, r *http.Request) {
+	h.moderatePost(w, r, h.postUsecase.ApprovePost, "Post approved")
+}
+
+func (h *PostHandler) RejectPost(w http.ResponseWriter, r *http.Request) {
+	h.moderatePost(w, r, h.postUsecase.RejectPost, "Post rejected")
+}
+
+// moderatePost applies a moderation decision to the post in the route
+func (h *PostHandler) moderatePost(w http.ResponseWriter, r *http.Request, moderate func(ctx context.Context, postID, userID int64) error, message string) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid ID"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = moderate(r.Context(), id, user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": message})
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// optionalTime parses an optional RFC 3339 timestamp or YYYY-MM-DD date query
+// parameter, returning nil when it is missing. A date used as an exclusive end
+// is moved to the next day so the whole day is included.
+func optionalTime(value string, endOfRange bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return &t, nil
+	}
+
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfRange {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// optionalInt parses an optional integer query parameter, returning 0 when it is missing
+func optionalInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// commentView parses the comment-view query parameter, reporting whether
+// comments are requested as a tree
+func commentView(value string) (threaded bool, err error) {
+	switch value {
+	case "", "flat":
+		return false, nil
+	case "tree":
+		return true, nil
+	}
+	return false, fmt.Errorf("unknown comment view %q", value)
+}
+
+// clientIP returns the IP address the request came from, forwarding headers
+// are ignored as clients can set them freely
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -7,18 +7,25 @@ import (
 	"github.com/suhriar/blog-mono-api/internal/delivery/middleware"
 )
 
-func RegisterRoutes(router *mux.Router, userHandler *UserHandler, postHandler *PostHandler) {
+func RegisterRoutes(router *mux.Router, jwtMiddleware *middleware.JWTMiddleware, userHandler *UserHandler, postHandler *PostHandler, searchHandler *SearchHandler, bookmarkHandler *BookmarkHandler, trendingHandler *TrendingHandler, followHandler *FollowHandler, blockHandler *BlockHandler) {
 	router.Use(middleware.LoggingMiddleware)
 
 	apiRouter := router.PathPrefix("/api").Subrouter()
 
 	apiRouter.HandleFunc("/health", HealthCheck).Methods("GET")
 
-	jwtMiddleware := middleware.NewJWTMiddleware()
-
 	// Register user routes
 	registerUserRoutes(apiRouter, userHandler, jwtMiddleware)
+	registerUserPostRoutes(apiRouter, postHandler, jwtMiddleware)
 	registerPostRoutes(apiRouter, postHandler, jwtMiddleware)
+	registerTrashRoutes(apiRouter, postHandler, jwtMiddleware)
+	registerTagRoutes(apiRouter, postHandler, jwtMiddleware)
+	registerSearchRoutes(apiRouter, searchHandler, jwtMiddleware)
+	registerBookmarkRoutes(apiRouter, bookmarkHandler, jwtMiddleware)
+	registerTrendingRoutes(apiRouter, trendingHandler, jwtMiddleware)
+	registerFollowRoutes(apiRouter, followHandler, jwtMiddleware)
+	registerFeedRoutes(apiRouter, postHandler, jwtMiddleware)
+	registerBlockRoutes(apiRouter, blockHandler, jwtMiddleware)
 }
 
 func registerUserRoutes(router *mux.Router, handler *UserHandler, jwtMiddleware *middleware.JWTMiddleware) {
@@ -27,11 +34,35 @@ func registerUserRoutes(router *mux.Router, handler *UserHandler, jwtMiddleware
 	// Public routes
 	userRouter.HandleFunc("/sign-up", handler.SignUp).Methods("POST")
 	userRouter.HandleFunc("/login", handler.Login).Methods("POST")
+	userRouter.HandleFunc("/password/forgot", handler.ForgotPassword).Methods("POST")
+	userRouter.HandleFunc("/password/reset", handler.ResetPassword).Methods("POST")
+	userRouter.HandleFunc("/email/verify", handler.VerifyEmail).Methods("GET")
+	userRouter.HandleFunc("/email/resend", handler.ResendEmailVerification).Methods("POST")
+
+	// Protected routes open to users who have not verified their email
+	unverified := userRouter.PathPrefix("").Subrouter()
+	unverified.Use(jwtMiddleware.AllowUnverified)
+	unverified.HandleFunc("/refresh", handler.Refresh).Methods("POST")
+	unverified.HandleFunc("/me", handler.DeleteMe).Methods("DELETE")
+
+	// Protected routes
+	protected := userRouter.PathPrefix("").Subrouter()
+	protected.Use(jwtMiddleware.RequireAuth)
+	protected.HandleFunc("/me", handler.GetMe).Methods("GET")
+	protected.HandleFunc("/me", handler.UpdateMe).Methods("PATCH")
+	protected.HandleFunc("/me/password", handler.ChangePassword).Methods("POST")
+	protected.HandleFunc("/me/export", handler.ExportMe).Methods("GET")
+	protected.HandleFunc("/{username}", handler.GetProfile).Methods("GET")
+}
+
+// registerUserPostRoutes registers the post listings scoped to a user
+func registerUserPostRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	userRouter := router.PathPrefix("/users").Subrouter()
 
 	// Protected routes
 	protected := userRouter.PathPrefix("").Subrouter()
 	protected.Use(jwtMiddleware.RequireAuth)
-	protected.HandleFunc("/refresh", handler.Refresh).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}/likes", handler.GetLikedPosts).Methods("GET")
 }
 
 func registerPostRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware *middleware.JWTMiddleware) {
@@ -42,9 +73,109 @@ func registerPostRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware
 	protected.Use(jwtMiddleware.RequireAuth)
 	protected.HandleFunc("/create", handler.CreatePost).Methods("POST")
 	protected.HandleFunc("/", handler.GetAllPost).Methods("GET")
+	protected.HandleFunc("/pending", handler.GetPendingPosts).Methods("GET")
 	protected.HandleFunc("/{id:[0-9]+}", handler.GetPostByID).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}", handler.UpdatePost).Methods("PUT")
+	protected.HandleFunc("/{id:[0-9]+}", handler.DeletePost).Methods("DELETE")
+	protected.HandleFunc("/{id:[0-9]+}/status", handler.UpdatePostStatus).Methods("PUT")
+	protected.HandleFunc("/{id:[0-9]+}/approve", handler.ApprovePost).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}/reject", handler.RejectPost).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}/revisions", handler.GetPostRevisions).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/revisions/{rev:[0-9]+}", handler.GetPostRevision).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/revisions/{rev:[0-9]+}/restore", handler.RestorePostRevision).Methods("POST")
 	protected.HandleFunc("/{id:[0-9]+}/comment", handler.CreateComment).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}/comments", handler.GetComments).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/comments/pending", handler.GetPendingComments).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/comment-settings", handler.UpdateCommentSettings).Methods("PUT")
+	protected.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}", handler.UpdateComment).Methods("PUT")
+	protected.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}/replies", handler.GetCommentReplies).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}", handler.DeleteComment).Methods("DELETE")
+	protected.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}/approve", handler.ApproveComment).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}/reject", handler.RejectComment).Methods("POST")
 	protected.HandleFunc("/{id:[0-9]+}/user-activity", handler.UpsertUserActivity).Methods("PUT")
+	protected.HandleFunc("/{id:[0-9]+}/likes", handler.GetPostLikes).Methods("GET")
+}
+
+func registerTrashRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	trashRouter := router.PathPrefix("/trash").Subrouter()
+
+	// Protected routes
+	protected := trashRouter.PathPrefix("").Subrouter()
+	protected.Use(jwtMiddleware.RequireAuth)
+	protected.HandleFunc("", handler.GetTrash).Methods("GET")
+	protected.HandleFunc("/posts/{id:[0-9]+}/restore", handler.RestorePost).Methods("POST")
+	protected.HandleFunc("/comments/{id:[0-9]+}/restore", handler.RestoreComment).Methods("POST")
+}
+
+func registerTagRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	tagRouter := router.PathPrefix("/tags").Subrouter()
+
+	// Protected routes
+	protected := tagRouter.PathPrefix("").Subrouter()
+	protected.Use(jwtMiddleware.RequireAuth)
+	protected.HandleFunc("", handler.GetTags).Methods("GET")
+	protected.HandleFunc("/{name}/posts", handler.GetPostsByTag).Methods("GET")
+}
+
+func registerSearchRoutes(router *mux.Router, handler *SearchHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	searchRouter := router.PathPrefix("/search").Subrouter()
+
+	// Protected routes
+	protected := searchRouter.PathPrefix("").Subrouter()
+	protected.Use(jwtMiddleware.RequireAuth)
+	protected.HandleFunc("", handler.Search).Methods("GET")
+}
+
+func registerBookmarkRoutes(router *mux.Router, handler *BookmarkHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	postRouter := router.PathPrefix("/posts").Subrouter()
+	postRouter.Use(jwtMiddleware.RequireAuth)
+	postRouter.HandleFunc("/{id:[0-9]+}/bookmark", handler.BookmarkPost).Methods("POST")
+	postRouter.HandleFunc("/{id:[0-9]+}/bookmark", handler.RemoveBookmark).Methods("DELETE")
+
+	meRouter := router.PathPrefix("/me").Subrouter()
+	meRouter.Use(jwtMiddleware.RequireAuth)
+	meRouter.HandleFunc("/bookmarks", handler.GetBookmarks).Methods("GET")
+	meRouter.HandleFunc("/bookmarks/collections", handler.GetBookmarkCollections).Methods("GET")
+}
+
+func registerTrendingRoutes(router *mux.Router, handler *TrendingHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	postRouter := router.PathPrefix("/posts").Subrouter()
+	postRouter.Use(jwtMiddleware.RequireAuth)
+	postRouter.HandleFunc("/trending", handler.GetTrendingPosts).Methods("GET")
+
+	tagRouter := router.PathPrefix("/tags").Subrouter()
+	tagRouter.Use(jwtMiddleware.RequireAuth)
+	tagRouter.HandleFunc("/trending", handler.GetTrendingTags).Methods("GET")
+}
+
+func registerFollowRoutes(router *mux.Router, handler *FollowHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	userRouter := router.PathPrefix("/users").Subrouter()
+	userRouter.Use(jwtMiddleware.RequireAuth)
+	userRouter.HandleFunc("/{id:[0-9]+}/follow", handler.FollowUser).Methods("POST")
+	userRouter.HandleFunc("/{id:[0-9]+}/follow", handler.UnfollowUser).Methods("DELETE")
+	userRouter.HandleFunc("/{id:[0-9]+}/followers", handler.GetFollowers).Methods("GET")
+	userRouter.HandleFunc("/{id:[0-9]+}/following", handler.GetFollowing).Methods("GET")
+	userRouter.HandleFunc("/{id:[0-9]+}/follow-counts", handler.GetFollowCounts).Methods("GET")
+}
+
+func registerFeedRoutes(router *mux.Router, handler *PostHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	feedRouter := router.PathPrefix("/feed").Subrouter()
+	feedRouter.Use(jwtMiddleware.RequireAuth)
+	feedRouter.HandleFunc("", handler.GetFeed).Methods("GET")
+}
+
+func registerBlockRoutes(router *mux.Router, handler *BlockHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	userRouter := router.PathPrefix("/users").Subrouter()
+	userRouter.Use(jwtMiddleware.RequireAuth)
+	userRouter.HandleFunc("/{id:[0-9]+}/block", handler.BlockUser).Methods("POST")
+	userRouter.HandleFunc("/{id:[0-9]+}/block", handler.UnblockUser).Methods("DELETE")
+	userRouter.HandleFunc("/{id:[0-9]+}/mute", handler.MuteUser).Methods("POST")
+	userRouter.HandleFunc("/{id:[0-9]+}/mute", handler.UnmuteUser).Methods("DELETE")
+
+	meRouter := router.PathPrefix("/me").Subrouter()
+	meRouter.Use(jwtMiddleware.RequireAuth)
+	meRouter.HandleFunc("/blocks", handler.GetBlockedUsers).Methods("GET")
+	meRouter.HandleFunc("/mutes", handler.GetMutedUsers).Methods("GET")
 }
 
 // HealthCheck handler for the health endpoint
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+type SearchHandler struct {
+	searchUsecase usecase.SearchUsecase
+}
+
+func NewSearchHandler(searchUsecase usecase.SearchUsecase) *SearchHandler {
+	return &SearchHandler{searchUsecase: searchUsecase}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	includeComments := false
+	if value := params.Get("comments"); value != "" {
+		includeComments, err = strconv.ParseBool(value)
+		if err != nil {
+			utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid comments"})
+			return
+		}
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.searchUsecase.Search(r.Context(), params.Get("q"), includeComments, user.ID, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/suhriar/blog-mono-api/internal/usecase"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+type TrendingHandler struct {
+	trendingUsecase usecase.TrendingUsecase
+}
+
+func NewTrendingHandler(trendingUsecase usecase.TrendingUsecase) *TrendingHandler {
+	return &TrendingHandler{trendingUsecase: trendingUsecase}
+}
+
+// GetTrendingPosts lists the posts of the last computed ranking, best scored first
+func (h *TrendingHandler) GetTrendingPosts(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.trendingUsecase.GetTrendingPosts(r.Context(), user.ID, pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// GetTrendingTags lists the hashtags of the last computed ranking, best scored first
+func (h *TrendingHandler) GetTrendingTags(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	pageIndex, err := optionalInt(params.Get("page-index"))
+	if err != nil || pageIndex < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-index"})
+		return
+	}
+
+	pageSize, err := optionalInt(params.Get("page-size"))
+	if err != nil || pageSize < 0 {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid page-size"})
+		return
+	}
+
+	res, err := h.trendingUsecase.GetTrendingTags(r.Context(), pageSize, pageIndex)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
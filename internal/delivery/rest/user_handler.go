@@ -1,9 +1,12 @@
 package rest
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
 	"github.com/suhriar/blog-mono-api/internal/usecase"
 	"github.com/suhriar/blog-mono-api/model"
 	"github.com/suhriar/blog-mono-api/pkg/utils"
@@ -26,7 +29,7 @@ func (h *UserHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 
 	err := h.userUsecase.SignUp(r.Context(), request)
 	if err != nil {
-		utils.RespondWithJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		respondWithError(w, err)
 		return
 	}
 
@@ -76,3 +79,241 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		},
 	)
 }
+
+func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.userUsecase.GetMe(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// UpdateMe changes the profile fields present in the request body
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	var request model.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.userUsecase.UpdateMe(r.Context(), user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.userUsecase.GetProfile(r.Context(), vars["username"], user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// ChangePassword sets a new password, signing the user out of every session
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var request model.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.userUsecase.ChangePassword(r.Context(), user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Password changed"})
+}
+
+// ForgotPassword responds the same whether or not the email has an account
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request model.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	err := h.userUsecase.ForgotPassword(r.Context(), request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "If the email is registered, a reset link has been sent"})
+}
+
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request model.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	err := h.userUsecase.ResetPassword(r.Context(), request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Password reset"})
+}
+
+// VerifyEmail is the target of the link in the verification mail, the token is
+// in the query
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	err := h.userUsecase.VerifyEmail(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Email verified"})
+}
+
+// ResendEmailVerification responds the same whether or not a link was sent
+func (h *UserHandler) ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	var request model.ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	err := h.userUsecase.ResendEmailVerification(r.Context(), request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "If the email is registered and not verified, a verification link has been sent"})
+}
+
+// ExportMe downloads the data of the user as a JSON document, or as a ZIP
+// archive with one JSON file per section when the format query parameter is zip
+func (h *UserHandler) ExportMe(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "zip" {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid format"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	res, err := h.userUsecase.ExportMe(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	if format != "zip" {
+		w.Header().Set("Content-Disposition", `attachment; filename="export.json"`)
+		utils.RespondWithJSON(w, http.StatusOK, res)
+		return
+	}
+
+	archive, err := exportArchive(res)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="export.zip"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
+
+// DeleteMe deletes the account, the request body confirms it with the password
+func (h *UserHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	var request model.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+		return
+	}
+
+	user, err := utils.GetUserFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.userUsecase.DeleteMe(r.Context(), user.ID, request)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Account deleted"})
+}
+
+// exportArchive zips the sections of the export into one JSON file each
+func exportArchive(export model.UserExport) ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"posts.json", export.Posts},
+		{"comments.json", export.Comments},
+		{"reactions.json", export.Reactions},
+		{"bookmarks.json", export.Bookmarks},
+		{"following.json", export.Following},
+		{"sessions.json", export.Sessions},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
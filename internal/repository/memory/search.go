@@ -0,0 +1,254 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	repository "github.com/suhriar/blog-mono-api/internal/repository/mysql"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/search"
+)
+
+// field weights, so a match in the title or a hashtag ranks above a match in the body
+const (
+	titleWeight   = 2.0
+	hashtagWeight = 2.0
+	contentWeight = 1.0
+)
+
+var _ repository.SearchRepository = (*SearchIndex)(nil)
+
+type document struct {
+	kind string
+	id   int64
+}
+
+// SearchIndex is a pure Go inverted index over posts and comments. It follows
+// the same visibility rules as the MySQL implementation, including approved
+// comments only and the blocks and mutes recorded with Block and Mute, and is
+// meant for tests and local development.
+type SearchIndex struct {
+	mu       sync.RWMutex
+	posts    map[int64]model.Post
+	comments map[int64]model.Comment
+	postings map[string]map[document]float64
+	terms    map[document][]string
+	blocks   map[int64]map[int64]bool
+	mutes    map[int64]map[int64]bool
+}
+
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		posts:    make(map[int64]model.Post),
+		comments: make(map[int64]model.Comment),
+		postings: make(map[string]map[document]float64),
+		terms:    make(map[document][]string),
+		blocks:   make(map[int64]map[int64]bool),
+		mutes:    make(map[int64]map[int64]bool),
+	}
+}
+
+// IndexPost adds the post to the index, replacing any previous version of it
+func (i *SearchIndex) IndexPost(post model.Post) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	weights := map[string]float64{}
+	addTerms(weights, post.PostTitle, titleWeight)
+	addTerms(weights, post.PostContent, contentWeight)
+	for _, hashtag := range post.PostHashtags {
+		addTerms(weights, hashtag, hashtagWeight)
+	}
+
+	i.posts[post.ID] = post
+	i.index(document{kind: model.SearchResultTypePost, id: post.ID}, weights)
+}
+
+// IndexComment adds the comment to the index, replacing any previous version of it
+func (i *SearchIndex) IndexComment(comment model.Comment) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	weights := map[string]float64{}
+	addTerms(weights, comment.CommentContent, contentWeight)
+
+	i.comments[comment.ID] = comment
+	i.index(document{kind: model.SearchResultTypeComment, id: comment.ID}, weights)
+}
+
+func (i *SearchIndex) RemovePost(id int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.posts, id)
+	i.unindex(document{kind: model.SearchResultTypePost, id: id})
+}
+
+func (i *SearchIndex) RemoveComment(id int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.comments, id)
+	i.unindex(document{kind: model.SearchResultTypeComment, id: id})
+}
+
+// Block hides the posts of the blocker, with their comments, from the blocked user
+func (i *SearchIndex) Block(blockerID, blockedID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	addRelation(i.blocks, blockedID, blockerID)
+}
+
+func (i *SearchIndex) Unblock(blockerID, blockedID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.blocks[blockedID], blockerID)
+}
+
+// Mute hides the posts of the muted user, with their comments, from the muter
+func (i *SearchIndex) Mute(muterID, mutedID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	addRelation(i.mutes, muterID, mutedID)
+}
+
+func (i *SearchIndex) Unmute(muterID, mutedID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.mutes[muterID], mutedID)
+}
+
+// Search scores every matching document with a weighted TF-IDF over the query terms
+func (i *SearchIndex) Search(ctx context.Context, query model.SearchQuery) (hits []model.SearchHit, err error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	total := float64(len(i.terms))
+	scores := map[document]float64{}
+	for _, term := range uniqueTerms(query.Query) {
+		postings := i.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + total/float64(len(postings)))
+		for doc, weight := range postings {
+			scores[doc] += weight * idf
+		}
+	}
+
+	hits = []model.SearchHit{}
+	for doc, score := range scores {
+		hit, ok := i.hit(doc, query)
+		if !ok {
+			continue
+		}
+		hit.Score = score
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		if hits[a].PostID != hits[b].PostID {
+			return hits[a].PostID > hits[b].PostID
+		}
+		return hits[a].CommentID < hits[b].CommentID
+	})
+
+	if query.Offset >= len(hits) {
+		return []model.SearchHit{}, nil
+	}
+	hits = hits[query.Offset:]
+	if query.Limit < len(hits) {
+		hits = hits[:query.Limit]
+	}
+	return hits, nil
+}
+
+// hit builds the search hit of the document, reporting false when the viewer may not see it
+func (i *SearchIndex) hit(doc document, query model.SearchQuery) (hit model.SearchHit, ok bool) {
+	switch doc.kind {
+	case model.SearchResultTypePost:
+		post := i.posts[doc.id]
+		if !i.visible(post, query.ViewerID) {
+			return hit, false
+		}
+		return model.SearchHit{Type: doc.kind, PostID: post.ID, PostTitle: post.PostTitle, Content: post.PostContent}, true
+	case model.SearchResultTypeComment:
+		comment := i.comments[doc.id]
+		post, exists := i.posts[comment.PostID]
+		if !query.IncludeComments || comment.Status != model.CommentStatusApproved || comment.DeletedAt != nil || !exists || !i.visible(post, query.ViewerID) {
+			return hit, false
+		}
+		return model.SearchHit{Type: doc.kind, PostID: post.ID, CommentID: comment.ID, PostTitle: post.PostTitle, Content: comment.CommentContent}, true
+	}
+	return hit, false
+}
+
+func (i *SearchIndex) index(doc document, weights map[string]float64) {
+	i.unindex(doc)
+
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[document]float64)
+		}
+		i.postings[term][doc] = weight
+		terms = append(terms, term)
+	}
+	i.terms[doc] = terms
+}
+
+func (i *SearchIndex) unindex(doc document) {
+	for _, term := range i.terms[doc] {
+		delete(i.postings[term], doc)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.terms, doc)
+}
+
+// visible reports whether the viewer may see the post, leaving out the posts of
+// authors blocking or muted by the viewer
+func (i *SearchIndex) visible(post model.Post, viewerID int64) bool {
+	if post.DeletedAt != nil || i.blocks[viewerID][post.UserID] || i.mutes[viewerID][post.UserID] {
+		return false
+	}
+	return post.Status == model.PostStatusPublished || post.UserID == viewerID
+}
+
+// addRelation records the relation of the user with the other user, keyed by
+// the user the relation is looked up for
+func addRelation(relations map[int64]map[int64]bool, userID, otherID int64) {
+	if relations[userID] == nil {
+		relations[userID] = make(map[int64]bool)
+	}
+	relations[userID][otherID] = true
+}
+
+func addTerms(weights map[string]float64, text string, weight float64) {
+	for _, term := range search.Tokenize(text) {
+		weights[term] += weight
+	}
+}
+
+func uniqueTerms(query string) []string {
+	seen := map[string]bool{}
+	terms := []string{}
+	for _, term := range search.Tokenize(query) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
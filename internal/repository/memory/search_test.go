@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func newTestIndex() *SearchIndex {
+	now := time.Now()
+	index := NewSearchIndex()
+	index.IndexPost(model.Post{ID: 1, UserID: 1, PostTitle: "Intro to Go", PostContent: "Go makes concurrency simple", Status: model.PostStatusPublished})
+	index.IndexPost(model.Post{ID: 2, UserID: 1, PostTitle: "Rust notes", PostContent: "Borrowing explained, unlike go", Status: model.PostStatusPublished})
+	index.IndexPost(model.Post{ID: 3, UserID: 2, PostTitle: "Cooking", PostContent: "Pasta recipes", PostHashtags: []string{"go"}, Status: model.PostStatusPublished})
+	index.IndexPost(model.Post{ID: 4, UserID: 2, PostTitle: "Draft about go", PostContent: "Unfinished", Status: model.PostStatusDraft})
+	index.IndexPost(model.Post{ID: 5, UserID: 1, PostTitle: "Trashed go post", PostContent: "Gone", Status: model.PostStatusPublished, DeletedAt: &now})
+	index.IndexComment(model.Comment{ID: 10, PostID: 2, UserID: 2, CommentContent: "I prefer go for services", Status: model.CommentStatusApproved})
+	index.IndexComment(model.Comment{ID: 11, PostID: 4, UserID: 1, CommentContent: "go go go", Status: model.CommentStatusApproved})
+	index.IndexComment(model.Comment{ID: 12, PostID: 1, UserID: 2, CommentContent: "go is pending approval", Status: model.CommentStatusPending})
+	return index
+}
+
+func TestSearchIndex_Search(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Ranks Title Matches First And Hides Invisible Posts", func(t *testing.T) {
+		index := newTestIndex()
+
+		hits, err := index.Search(ctx, model.SearchQuery{Query: "Go", ViewerID: 1, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, hits, 3)
+		assert.Equal(t, int64(1), hits[0].PostID)
+		for _, hit := range hits {
+			assert.Equal(t, model.SearchResultTypePost, hit.Type)
+			assert.NotContains(t, []int64{4, 5}, hit.PostID)
+		}
+	})
+
+	t.Run("Author Sees Own Drafts And Comments On Demand", func(t *testing.T) {
+		index := newTestIndex()
+
+		hits, err := index.Search(ctx, model.SearchQuery{Query: "go", IncludeComments: true, ViewerID: 2, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Len(t, hits, 6)
+
+		var comments []int64
+		for _, hit := range hits {
+			if hit.Type == model.SearchResultTypeComment {
+				comments = append(comments, hit.CommentID)
+			}
+		}
+		assert.ElementsMatch(t, []int64{10, 11}, comments)
+	})
+
+	t.Run("Hides Posts Of Blocking And Muted Authors With Their Comments", func(t *testing.T) {
+		index := newTestIndex()
+		index.Block(2, 1)
+
+		hits, err := index.Search(ctx, model.SearchQuery{Query: "go", IncludeComments: true, ViewerID: 1, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, hits, 3)
+		for _, hit := range hits {
+			assert.NotEqual(t, int64(3), hit.PostID)
+		}
+
+		index.Unblock(2, 1)
+		index.Mute(2, 1)
+
+		hits, err = index.Search(ctx, model.SearchQuery{Query: "go", IncludeComments: true, ViewerID: 2, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, hits, 3)
+		for _, hit := range hits {
+			assert.NotContains(t, []int64{1, 2}, hit.PostID)
+		}
+	})
+
+	t.Run("Paginates", func(t *testing.T) {
+		index := newTestIndex()
+
+		all, err := index.Search(ctx, model.SearchQuery{Query: "go", ViewerID: 1, Limit: 10})
+		assert.NoError(t, err)
+
+		page, err := index.Search(ctx, model.SearchQuery{Query: "go", ViewerID: 1, Limit: 1, Offset: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, all[1:2], page)
+
+		page, err = index.Search(ctx, model.SearchQuery{Query: "go", ViewerID: 1, Limit: 1, Offset: 5})
+		assert.NoError(t, err)
+		assert.Empty(t, page)
+	})
+
+	t.Run("Reindex And Remove", func(t *testing.T) {
+		index := newTestIndex()
+		index.IndexPost(model.Post{ID: 1, UserID: 1, PostTitle: "Intro to Zig", PostContent: "Comptime", Status: model.PostStatusPublished})
+		index.RemovePost(3)
+
+		hits, err := index.Search(ctx, model.SearchQuery{Query: "go", ViewerID: 1, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, hits, 1)
+		assert.Equal(t, int64(2), hits[0].PostID)
+
+		hits, err = index.Search(ctx, model.SearchQuery{Query: "zig", ViewerID: 1, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, hits, 1)
+	})
+}
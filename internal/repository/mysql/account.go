@@ -0,0 +1,217 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// GetUserExport collects the posts, comments, reactions, bookmarks, follows
+// and sessions of the user, trashed and unpublished ones included
+func (r *userRepository) GetUserExport(ctx context.Context, userID int64) (export model.UserExport, err error) {
+	export.Posts = []model.Post{}
+	err = scanEach(ctx, r.db, `SELECT id, user_id, post_title, post_content, status, publish_at, comment_mode, created_at, updated_at, created_by, updated_by, deleted_at
+	FROM posts WHERE user_id = ? ORDER BY id`, userID, func(rows *sql.Rows) error {
+		var post model.Post
+		if err := rows.Scan(&post.ID, &post.UserID, &post.PostTitle, &post.PostContent, &post.Status, &post.PublishAt, &post.CommentMode,
+			&post.CreatedAt, &post.UpdatedAt, &post.CreatedBy, &post.UpdatedBy, &post.DeletedAt); err != nil {
+			return err
+		}
+		export.Posts = append(export.Posts, post)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	postIDs := make([]int64, 0, len(export.Posts))
+	for _, post := range export.Posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	tags, err := getTagsByPostIDs(ctx, r.db, postIDs)
+	if err != nil {
+		return
+	}
+	for i := range export.Posts {
+		export.Posts[i].PostHashtags = tags[export.Posts[i].ID]
+	}
+
+	export.Comments = []model.ExportedComment{}
+	err = scanEach(ctx, r.db, `SELECT id, post_id, parent_comment_id, comment_content, status, edited_at, created_at, deleted_at
+	FROM comments WHERE user_id = ? ORDER BY id`, userID, func(rows *sql.Rows) error {
+		var comment model.ExportedComment
+		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.ParentCommentID, &comment.CommentContent, &comment.Status,
+			&comment.EditedAt, &comment.CreatedAt, &comment.DeletedAt); err != nil {
+			return err
+		}
+		export.Comments = append(export.Comments, comment)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	export.Reactions = []model.ExportedReaction{}
+	err = scanEach(ctx, r.db, `SELECT post_id, reaction, updated_at FROM user_activities WHERE user_id = ? AND reaction IS NOT NULL ORDER BY id`, userID, func(rows *sql.Rows) error {
+		var reaction model.ExportedReaction
+		if err := rows.Scan(&reaction.PostID, &reaction.Reaction, &reaction.ReactedAt); err != nil {
+			return err
+		}
+		export.Reactions = append(export.Reactions, reaction)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	export.Bookmarks = []model.ExportedBookmark{}
+	err = scanEach(ctx, r.db, `SELECT post_id, collection, created_at FROM bookmarks WHERE user_id = ? ORDER BY id`, userID, func(rows *sql.Rows) error {
+		var bookmark model.ExportedBookmark
+		if err := rows.Scan(&bookmark.PostID, &bookmark.Collection, &bookmark.BookmarkedAt); err != nil {
+			return err
+		}
+		export.Bookmarks = append(export.Bookmarks, bookmark)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	export.Following = []model.FollowUser{}
+	err = scanEach(ctx, r.db, `SELECT u.id, u.username, f.created_at FROM follows f JOIN users u ON u.id = f.followee_id
+	WHERE f.follower_id = ? ORDER BY f.created_at`, userID, func(rows *sql.Rows) error {
+		var follow model.FollowUser
+		if err := rows.Scan(&follow.UserID, &follow.Username, &follow.FollowedAt); err != nil {
+			return err
+		}
+		export.Following = append(export.Following, follow)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	export.Sessions = []model.ExportedSession{}
+	err = scanEach(ctx, r.db, `SELECT created_at, expired_at FROM refresh_tokens WHERE user_id = ? ORDER BY id`, userID, func(rows *sql.Rows) error {
+		var session model.ExportedSession
+		if err := rows.Scan(&session.CreatedAt, &session.ExpiredAt); err != nil {
+			return err
+		}
+		export.Sessions = append(export.Sessions, session)
+		return nil
+	})
+	return
+}
+
+// AnonymizeUser deletes the private data of the user and turns the account into
+// a placeholder that cannot sign in, keeping their posts and comments
+func (r *userRepository) AnonymizeUser(ctx context.Context, userID int64, now time.Time) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = deleteUserRelations(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	placeholder := fmt.Sprintf("deleted-%d", userID)
+	query := `UPDATE users SET email = ?, username = ?, password = '', display_name = '', bio = '', avatar_url = '', website = '', location = '',
+	email_verified_at = NULL, created_by = ?, updated_at = ?, updated_by = ?, deleted_at = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, placeholder+"@deleted.invalid", placeholder, strconv.FormatInt(userID, 10), now, strconv.FormatInt(userID, 10), now, userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteUser removes the user with their posts and comments. Rows referencing
+// the posts of the user, including other users' comments and reactions, are
+// removed first to satisfy the foreign keys. Revisions the user made of other
+// users' posts are kept without an editor.
+func (r *userRepository) DeleteUser(ctx context.Context, userID int64) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = deleteUserRelations(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	queries := []string{
+		`DELETE ua FROM user_activities ua JOIN posts p ON ua.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE c FROM comments c JOIN posts p ON c.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE pt FROM post_tags pt JOIN posts p ON pt.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE pr FROM post_revisions pr JOIN posts p ON pr.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE b FROM bookmarks b JOIN posts p ON b.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE vb FROM post_view_buckets vb JOIN posts p ON vb.post_id = p.id WHERE p.user_id = ?`,
+		`DELETE tp FROM trending_posts tp JOIN posts p ON tp.post_id = p.id WHERE p.user_id = ?`,
+		`UPDATE post_revisions SET editor_id = NULL WHERE editor_id = ?`,
+		`DELETE FROM comments WHERE user_id = ?`,
+		`DELETE FROM posts WHERE user_id = ?`,
+		`DELETE FROM users WHERE id = ?`,
+	}
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// deleteUserRelations deletes the rows that only matter to the user: their
+// reactions, bookmarks, follows, blocks, mutes, sessions and pending tokens
+func deleteUserRelations(ctx context.Context, tx *sql.Tx, userID int64) (err error) {
+	queries := []string{
+		`DELETE FROM user_activities WHERE user_id = ?`,
+		`DELETE FROM bookmarks WHERE user_id = ?`,
+		`DELETE FROM follows WHERE follower_id = ? OR followee_id = ?`,
+		`DELETE FROM blocks WHERE blocker_id = ? OR blocked_id = ?`,
+		`DELETE FROM mutes WHERE muter_id = ? OR muted_id = ?`,
+		`DELETE FROM refresh_tokens WHERE user_id = ?`,
+		`DELETE FROM password_resets WHERE user_id = ?`,
+		`DELETE FROM email_verifications WHERE user_id = ?`,
+	}
+	for _, query := range queries {
+		args := make([]interface{}, strings.Count(query, "?"))
+		for i := range args {
+			args[i] = userID
+		}
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanEach runs the query for the user and calls scan for every row
+func scanEach(ctx context.Context, db *sql.DB, query string, userID int64, scan func(rows *sql.Rows) error) (err error) {
+	rows, err := db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
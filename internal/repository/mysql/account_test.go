@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func expectDeleteUserRelations(mock sqlmock.Sqlmock, userID int64) {
+	mock.ExpectExec(`DELETE FROM user_activities WHERE user_id = \?`).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM bookmarks WHERE user_id = \?`).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM follows WHERE follower_id = \? OR followee_id = \?`).WithArgs(userID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM blocks WHERE blocker_id = \? OR blocked_id = \?`).WithArgs(userID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM mutes WHERE muter_id = \? OR muted_id = \?`).WithArgs(userID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM refresh_tokens WHERE user_id = \?`).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM password_resets WHERE user_id = \?`).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM email_verifications WHERE user_id = \?`).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestGetUserExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT id, user_id, post_title, post_content, status, publish_at, comment_mode, created_at, updated_at, created_by, updated_by, deleted_at FROM posts WHERE user_id = \? ORDER BY id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "created_by", "updated_by", "deleted_at"}).
+			AddRow(10, 1, "Draft", "Content", "draft", nil, "open", now, now, "1", "1", nil))
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).AddRow(10, "go"))
+	mock.ExpectQuery(`SELECT id, post_id, parent_comment_id, comment_content, status, edited_at, created_at, deleted_at FROM comments WHERE user_id = \? ORDER BY id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "parent_comment_id", "comment_content", "status", "edited_at", "created_at", "deleted_at"}).
+			AddRow(20, 11, nil, "Nice", "approved", nil, now, nil))
+	mock.ExpectQuery(`SELECT post_id, reaction, updated_at FROM user_activities WHERE user_id = \? AND reaction IS NOT NULL ORDER BY id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "reaction", "updated_at"}).AddRow(11, "love", now))
+	mock.ExpectQuery(`SELECT post_id, collection, created_at FROM bookmarks WHERE user_id = \? ORDER BY id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "collection", "created_at"}))
+	mock.ExpectQuery(`SELECT u.id, u.username, f.created_at FROM follows f JOIN users u ON u.id = f.followee_id WHERE f.follower_id = \? ORDER BY f.created_at`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at"}).AddRow(2, "bob", now))
+	mock.ExpectQuery(`SELECT created_at, expired_at FROM refresh_tokens WHERE user_id = \? ORDER BY id`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "expired_at"}).AddRow(now, now.Add(time.Hour)))
+
+	export, err := repo.GetUserExport(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Post{{ID: 10, UserID: 1, PostTitle: "Draft", PostContent: "Content", PostHashtags: []string{"go"}, Status: model.PostStatusDraft,
+		CommentMode: model.CommentModeOpen, CreatedAt: now, UpdatedAt: now, CreatedBy: "1", UpdatedBy: "1"}}, export.Posts)
+	assert.Equal(t, []model.ExportedComment{{ID: 20, PostID: 11, CommentContent: "Nice", Status: model.CommentStatusApproved, CreatedAt: now}}, export.Comments)
+	assert.Equal(t, []model.ExportedReaction{{PostID: 11, Reaction: "love", ReactedAt: now}}, export.Reactions)
+	assert.Equal(t, []model.ExportedBookmark{}, export.Bookmarks)
+	assert.Equal(t, []model.FollowUser{{UserID: 2, Username: "bob", FollowedAt: now}}, export.Following)
+	assert.Equal(t, []model.ExportedSession{{CreatedAt: now, ExpiredAt: now.Add(time.Hour)}}, export.Sessions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	expectDeleteUserRelations(mock, 1)
+	mock.ExpectExec(`UPDATE users SET email = \?, username = \?, password = '', display_name = '', bio = '', avatar_url = '', website = '', location = '', email_verified_at = NULL, created_by = \?, updated_at = \?, updated_by = \?, deleted_at = \? WHERE id = \?`).
+		WithArgs("deleted-1@deleted.invalid", "deleted-1", "1", now, "1", now, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.AnonymizeUser(context.Background(), 1, now)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success DeleteUser", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		expectDeleteUserRelations(mock, 1)
+		for _, query := range []string{
+			`DELETE ua FROM user_activities ua JOIN posts p ON ua.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE c FROM comments c JOIN posts p ON c.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE pt FROM post_tags pt JOIN posts p ON pt.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE pr FROM post_revisions pr JOIN posts p ON pr.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE b FROM bookmarks b JOIN posts p ON b.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE vb FROM post_view_buckets vb JOIN posts p ON vb.post_id = p.id WHERE p.user_id = \?`,
+			`DELETE tp FROM trending_posts tp JOIN posts p ON tp.post_id = p.id WHERE p.user_id = \?`,
+			`UPDATE post_revisions SET editor_id = NULL WHERE editor_id = \?`,
+			`DELETE FROM comments WHERE user_id = \?`,
+			`DELETE FROM posts WHERE user_id = \?`,
+			`DELETE FROM users WHERE id = \?`,
+		} {
+			mock.ExpectExec(query).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		err = repo.DeleteUser(ctx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail DeleteUser - Rolls Back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM user_activities WHERE user_id = \?`).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err = repo.DeleteUser(ctx, 1)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
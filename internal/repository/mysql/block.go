@@ -0,0 +1,124 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// CreateBlock blocks the user and ends the follows between both users,
+// blocking twice is a no-op
+func (r *blockRepository) CreateBlock(ctx context.Context, model model.Block) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT IGNORE INTO blocks (blocker_id, blocked_id, created_at) VALUES (?, ?, ?)`
+	if _, err = tx.ExecContext(ctx, query, model.BlockerID, model.BlockedID, model.CreatedAt); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM follows WHERE (follower_id = ? AND followee_id = ?) OR (follower_id = ? AND followee_id = ?)`
+	if _, err = tx.ExecContext(ctx, query, model.BlockerID, model.BlockedID, model.BlockedID, model.BlockerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteBlock unblocks the user, reporting whether they were blocked
+func (r *blockRepository) DeleteBlock(ctx context.Context, blockerID, blockedID int64) (deleted bool, err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM blocks WHERE blocker_id = ? AND blocked_id = ?`, blockerID, blockedID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetBlockedUsers lists the users blocked by the user, most recent first
+func (r *blockRepository) GetBlockedUsers(ctx context.Context, blockerID int64, limit, offset int) (resp model.GetRestrictedUsersResponse, err error) {
+	return r.getRestrictedUsers(ctx, "blocks", "blocker_id", "blocked_id", blockerID, limit, offset)
+}
+
+// CreateMute mutes the user, muting twice is a no-op
+func (r *blockRepository) CreateMute(ctx context.Context, model model.Mute) (err error) {
+	query := `INSERT IGNORE INTO mutes (muter_id, muted_id, created_at) VALUES (?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, model.MuterID, model.MutedID, model.CreatedAt)
+	return err
+}
+
+// DeleteMute unmutes the user, reporting whether they were muted
+func (r *blockRepository) DeleteMute(ctx context.Context, muterID, mutedID int64) (deleted bool, err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM mutes WHERE muter_id = ? AND muted_id = ?`, muterID, mutedID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetMutedUsers lists the users muted by the user, most recent first
+func (r *blockRepository) GetMutedUsers(ctx context.Context, muterID int64, limit, offset int) (resp model.GetRestrictedUsersResponse, err error) {
+	return r.getRestrictedUsers(ctx, "mutes", "muter_id", "muted_id", muterID, limit, offset)
+}
+
+// getRestrictedUsers pages through the rows of the table whose by column is the
+// user, listing the users of the target column. All names are fixed by the callers.
+func (r *blockRepository) getRestrictedUsers(ctx context.Context, table, by, target string, userID int64, limit, offset int) (resp model.GetRestrictedUsersResponse, err error) {
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE `+by+` = ?`, userID).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := `SELECT u.id, u.username, r.created_at FROM ` + table + ` r JOIN users u ON u.id = r.` + target + `
+	WHERE r.` + by + ` = ? ORDER BY r.created_at DESC, u.id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.RestrictedUser{}
+	for rows.Next() {
+		var user model.RestrictedUser
+		if err = rows.Scan(&user.UserID, &user.Username, &user.CreatedAt); err != nil {
+			return
+		}
+		data = append(data, user)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+// IsBlocked reports whether the blocker blocks the other user
+func (r *postRepository) IsBlocked(ctx context.Context, blockerID, blockedID int64) (blocked bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM blocks WHERE blocker_id = ? AND blocked_id = ?)`
+	err = r.db.QueryRowContext(ctx, query, blockerID, blockedID).Scan(&blocked)
+	return
+}
@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestCreateBlock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	block := model.Block{BlockerID: 1, BlockedID: 2, CreatedAt: now}
+
+	t.Run("Success CreateBlock", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &blockRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT IGNORE INTO blocks \(blocker_id, blocked_id, created_at\) VALUES \(\?, \?, \?\)`).
+			WithArgs(int64(1), int64(2), now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM follows WHERE \(follower_id = \? AND followee_id = \?\) OR \(follower_id = \? AND followee_id = \?\)`).
+			WithArgs(int64(1), int64(2), int64(2), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = repo.CreateBlock(ctx, block)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail CreateBlock - Rolls Back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &blockRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT IGNORE INTO blocks`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM follows`).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err = repo.CreateBlock(ctx, block)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteMute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &blockRepository{db: db}
+
+	mock.ExpectExec(`DELETE FROM mutes WHERE muter_id = \? AND muted_id = \?`).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	deleted, err := repo.DeleteMute(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockedUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &blockRepository{db: db}
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM blocks WHERE blocker_id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT u.id, u.username, r.created_at FROM blocks r JOIN users u ON u.id = r.blocked_id WHERE r.blocker_id = \? ORDER BY r.created_at DESC, u.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(int64(1), 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at"}).AddRow(2, "troll", now))
+
+	resp, err := repo.GetBlockedUsers(context.Background(), 1, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.RestrictedUser{{UserID: 2, Username: "troll", CreatedAt: now}}, resp.Data)
+	assert.Equal(t, model.Pagination{Limit: 10, Offset: 0, Total: 1, HasMore: false}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMutedUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &blockRepository{db: db}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM mutes WHERE muter_id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT u.id, u.username, r.created_at FROM mutes r JOIN users u ON u.id = r.muted_id WHERE r.muter_id = \?`).
+		WithArgs(int64(1), 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at"}))
+
+	resp, err := repo.GetMutedUsers(context.Background(), 1, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsBlocked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM blocks WHERE blocker_id = \? AND blocked_id = \?\)`).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"blocked"}).AddRow(true))
+
+	blocked, err := repo.IsBlocked(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
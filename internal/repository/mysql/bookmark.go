@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// UpsertBookmark bookmarks the post, moving it to the given collection when the
+// user already bookmarked it
+func (r *bookmarkRepository) UpsertBookmark(ctx context.Context, model model.Bookmark) (err error) {
+	query := `INSERT INTO bookmarks (user_id, post_id, collection, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE collection = VALUES(collection), updated_at = VALUES(updated_at)`
+	_, err = r.db.ExecContext(ctx, query, model.UserID, model.PostID, model.Collection, model.CreatedAt, model.UpdatedAt)
+	return err
+}
+
+// DeleteBookmark removes the bookmark, reporting whether there was one
+func (r *bookmarkRepository) DeleteBookmark(ctx context.Context, userID, postID int64) (deleted bool, err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM bookmarks WHERE user_id = ? AND post_id = ?`, userID, postID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetBookmarks lists the bookmarked posts of the user that are still visible to
+// them, most recent bookmark first. A nil collection lists every collection.
+// Posts of authors blocking or muted by the user are left out.
+func (r *bookmarkRepository) GetBookmarks(ctx context.Context, userID int64, collection *string, limit, offset int) (resp model.GetBookmarksResponse, err error) {
+	join := ` JOIN bookmarks b ON b.post_id = p.id AND b.user_id = ?`
+	where := ` WHERE p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)` + notBlockedCondition + notMutedCondition
+	args := []interface{}{userID, userID, userID, userID}
+	if collection != nil {
+		where += ` AND b.collection = ?`
+		args = append(args, *collection)
+	}
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts p`+join+where, args...).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := postDetailColumns + `, b.collection, b.created_at` + postDetailJoins + join + where + ` ORDER BY b.created_at DESC, b.id DESC LIMIT ? OFFSET ?`
+	args = append([]interface{}{userID, userID}, args...)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.BookmarkedPost{}
+	for rows.Next() {
+		var bookmark model.BookmarkedPost
+		err = rows.Scan(append(postDetailDest(&bookmark.Post), &bookmark.Collection, &bookmark.BookmarkedAt)...)
+		if err != nil {
+			return
+		}
+		data = append(data, bookmark)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	postIDs := make([]int64, 0, len(data))
+	for _, bookmark := range data {
+		postIDs = append(postIDs, bookmark.Post.ID)
+	}
+
+	tags, err := getTagsByPostIDs(ctx, r.db, postIDs)
+	if err != nil {
+		return
+	}
+
+	for i := range data {
+		data[i].Post.PostHashtags = tags[data[i].Post.ID]
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+// GetBookmarkCollections lists the collections of the user by name, the
+// default collection being the empty name. Only visible posts are counted.
+func (r *bookmarkRepository) GetBookmarkCollections(ctx context.Context, userID int64) (collections []model.BookmarkCollection, err error) {
+	query := `SELECT b.collection, COUNT(*) FROM bookmarks b JOIN posts p ON b.post_id = p.id
+	WHERE b.user_id = ? AND p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)` + notBlockedCondition + notMutedCondition + `
+	GROUP BY b.collection ORDER BY b.collection`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, userID, userID, userID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	collections = []model.BookmarkCollection{}
+	for rows.Next() {
+		var collection model.BookmarkCollection
+		if err = rows.Scan(&collection.Name, &collection.BookmarkCount); err != nil {
+			return
+		}
+		collections = append(collections, collection)
+	}
+	err = rows.Err()
+	return
+}
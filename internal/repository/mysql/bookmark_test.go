@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestUpsertBookmark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &bookmarkRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	bookmark := model.Bookmark{UserID: 1, PostID: 2, Collection: "later", CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectExec(`INSERT INTO bookmarks \(user_id, post_id, collection, created_at, updated_at\) VALUES \(\?, \?, \?, \?, \?\) ON DUPLICATE KEY UPDATE collection = VALUES\(collection\), updated_at = VALUES\(updated_at\)`).
+		WithArgs(bookmark.UserID, bookmark.PostID, bookmark.Collection, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.UpsertBookmark(ctx, bookmark)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBookmark(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success DeleteBookmark", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &bookmarkRepository{db: db}
+		mock.ExpectExec(`DELETE FROM bookmarks WHERE user_id = \? AND post_id = \?`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		deleted, err := repo.DeleteBookmark(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success DeleteBookmark - Not Bookmarked", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &bookmarkRepository{db: db}
+		mock.ExpectExec(`DELETE FROM bookmarks`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		deleted, err := repo.DeleteBookmark(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBookmarks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &bookmarkRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	userID := int64(3)
+	collection := "later"
+	where := `WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) AND b.collection = \?`
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked", "collection", "bookmarked_at"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p JOIN bookmarks b ON b.post_id = p.id AND b.user_id = \? `+where+`$`).
+		WithArgs(userID, userID, userID, userID, collection).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT p.id, .*, vb.post_id IS NOT NULL, b.collection, b.created_at FROM posts p .* JOIN bookmarks b ON b.post_id = p.id AND b.user_id = \? `+where+` ORDER BY b.created_at DESC, b.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(userID, userID, userID, userID, userID, userID, collection, 10, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 1, "author", "Title", "Content", "published", nil, "open", now, now, 2, 1, 0, false, true, collection, now))
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).AddRow(5, "go"))
+
+	resp, err := repo.GetBookmarks(ctx, userID, &collection, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, collection, resp.Data[0].Collection)
+	assert.Equal(t, now, resp.Data[0].BookmarkedAt)
+	assert.Equal(t, int64(5), resp.Data[0].Post.ID)
+	assert.True(t, resp.Data[0].Post.IsBookmarked)
+	assert.Equal(t, []string{"go"}, resp.Data[0].Post.PostHashtags)
+	assert.Equal(t, model.Pagination{Limit: 10, Offset: 0, Total: 1, HasMore: false}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBookmarkCollections(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &bookmarkRepository{db: db}
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT b.collection, COUNT\(\*\) FROM bookmarks b JOIN posts p ON b.post_id = p.id WHERE b.user_id = \? AND p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) GROUP BY b.collection ORDER BY b.collection`).
+		WithArgs(int64(1), int64(1), int64(1), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"collection", "count"}).AddRow("", 4).AddRow("later", 2))
+
+	collections, err := repo.GetBookmarkCollections(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.BookmarkCollection{{Name: "", BookmarkCount: 4}, {Name: "later", BookmarkCount: 2}}, collections)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
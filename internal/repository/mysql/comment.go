@@ -2,13 +2,16 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/suhriar/blog-mono-api/model"
 )
 
 func (r *postRepository) CreateComment(ctx context.Context, model model.Comment) (lastInsertID int64, err error) {
-	query := `INSERT INTO comments(post_id, user_id, comment_content, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, model.PostID, model.UserID, model.CommentContent, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
+	query := `INSERT INTO comments(post_id, user_id, parent_comment_id, comment_content, status, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, model.PostID, model.UserID, model.ParentCommentID, model.CommentContent, model.Status, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
 	if err != nil {
 		return
 	}
@@ -20,32 +23,295 @@ func (r *postRepository) CreateComment(ctx context.Context, model model.Comment)
 	return
 }
 
-func (r *postRepository) GetCommentsByPostID(ctx context.Context, postID int64) (comments []model.CommentResponse, err error) {
-	query := `SELECT c.id, c.user_id, c.comment_content, u.username 
+// commentSortKeys is the allow-list of comment sorts, top ranks comments by their direct replies
+var commentSortKeys = map[model.CommentSort]postSortKey{
+	model.CommentSortOldest: {expr: "c.created_at"},
+	model.CommentSortNewest: {expr: "c.created_at", desc: true},
+	model.CommentSortTop:    {expr: "COALESCE(rc.reply_count, 0)", desc: true, numeric: true},
+}
+
+// commentQuery selects the CommentResponse columns scanned by queryComments
+const commentQuery = `SELECT c.id, c.user_id, u.username, c.comment_content, c.parent_comment_id, c.created_at, c.edited_at, c.deleted_at
+	FROM comments c JOIN users u ON c.user_id = u.id`
+
+// commentVisibleCondition keeps the approved comments. Deleted comments are only
+// listed, as placeholders, while a reply at any depth below them is still live,
+// so every listed reply keeps its chain of parents.
+const commentVisibleCondition = `c.status = 'approved' AND (c.deleted_at IS NULL
+	OR EXISTS (WITH RECURSIVE descendants AS (
+		SELECT r.id, r.deleted_at FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'approved'
+		UNION ALL
+		SELECT r.id, r.deleted_at FROM comments r JOIN descendants d ON r.parent_comment_id = d.id WHERE r.status = 'approved'
+	) SELECT 1 FROM descendants WHERE deleted_at IS NULL))`
+
+// GetComments pages through the top level comments of the post by keyset on the
+// sort key and id. ReplyCount of the returned comments holds their direct replies.
+func (r *postRepository) GetComments(ctx context.Context, postID int64, sort model.CommentSort, page model.PageQuery) (resp model.GetCommentsResponse, err error) {
+	sortKey, ok := commentSortKeys[sort]
+	if !ok {
+		sortKey = commentSortKeys[model.CommentSortOldest]
+	}
+
+	where := ` WHERE c.post_id = ? AND c.parent_comment_id IS NULL AND ` + commentVisibleCondition
+	args := []interface{}{postID}
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM comments c`+where, args...).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := `SELECT c.id, c.user_id, u.username, c.comment_content, c.parent_comment_id, c.created_at, c.edited_at, c.deleted_at, COALESCE(rc.reply_count, 0)
 	FROM comments c JOIN users u ON c.user_id = u.id
-	WHERE c.post_id = ?`
+	LEFT JOIN (SELECT parent_comment_id, COUNT(*) AS reply_count FROM comments WHERE post_id = ? AND status = 'approved' AND deleted_at IS NULL GROUP BY parent_comment_id) rc ON rc.parent_comment_id = c.id` + where
+	args = append([]interface{}{postID}, args...)
+
+	// walking backward reverses the order, the page is flipped back below
+	desc := sortKey.desc
+	if cursor := page.Cursor; cursor != nil {
+		desc = desc != cursor.Backward
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		query += ` AND (` + sortKey.expr + ` ` + op + ` ? OR (` + sortKey.expr + ` = ? AND c.id ` + op + ` ?))`
+
+		var value interface{} = cursor.Time
+		if sortKey.numeric {
+			value = cursor.Count
+		}
+		args = append(args, value, value, cursor.ID)
+	}
+
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+
+	// fetch one extra row to know whether there is a further page
+	query += ` ORDER BY ` + sortKey.expr + ` ` + order + `, c.id ` + order + ` LIMIT ?`
+	args = append(args, page.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.CommentResponse{}
+	for rows.Next() {
+		var (
+			comment    model.CommentResponse
+			deletedAt  *time.Time
+			replyCount int
+		)
+		err = rows.Scan(&comment.ID, &comment.UserID, &comment.Username, &comment.CommentContent, &comment.ParentCommentID, &comment.CreatedAt, &comment.EditedAt, &deletedAt, &replyCount)
+		if err != nil {
+			return
+		}
+		comment = visibleComment(comment, deletedAt)
+		comment.ReplyCount = replyCount
+		data = append(data, comment)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	hasMore := len(data) > page.Limit
+	if hasMore {
+		data = data[:page.Limit]
+	}
+	if page.Cursor != nil && page.Cursor.Backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   page.Limit,
+		Total:   total,
+		HasMore: hasMore,
+	}
+	return
+}
+
+// GetCommentReplies lists the first replies below each of the given comments,
+// at any depth, in the order they were written. At most limit replies are
+// returned per comment, threadSizes holds how many visible replies each comment
+// has in total.
+func (r *postRepository) GetCommentReplies(ctx context.Context, commentIDs []int64, limit int) (replies []model.CommentResponse, threadSizes map[int64]int, err error) {
+	replies, threadSizes = []model.CommentResponse{}, make(map[int64]int, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return replies, threadSizes, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(commentIDs)), ", ")
+	args := make([]interface{}, 0, len(commentIDs)+1)
+	for _, commentID := range commentIDs {
+		args = append(args, commentID)
+	}
+	args = append(args, limit)
+
+	// replies are written after their parent, so the first replies of a thread
+	// never leave out the parent of an embedded reply
+	query := `WITH RECURSIVE thread AS (
+		SELECT id, parent_comment_id AS root_id FROM comments WHERE parent_comment_id IN (` + placeholders + `)
+		UNION ALL
+		SELECT r.id, t.root_id FROM comments r JOIN thread t ON r.parent_comment_id = t.id
+	)
+	SELECT id, user_id, username, comment_content, parent_comment_id, created_at, edited_at, deleted_at, root_id, thread_size FROM (
+		SELECT c.id, c.user_id, u.username, c.comment_content, c.parent_comment_id, c.created_at, c.edited_at, c.deleted_at, t.root_id,
+			ROW_NUMBER() OVER (PARTITION BY t.root_id ORDER BY c.created_at, c.id) AS position,
+			COUNT(*) OVER (PARTITION BY t.root_id) AS thread_size
+		FROM thread t JOIN comments c ON c.id = t.id JOIN users u ON c.user_id = u.id
+		WHERE ` + commentVisibleCondition + `
+	) ranked
+	WHERE position <= ?
+	ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			comment    model.CommentResponse
+			deletedAt  *time.Time
+			rootID     int64
+			threadSize int
+		)
+		err = rows.Scan(&comment.ID, &comment.UserID, &comment.Username, &comment.CommentContent, &comment.ParentCommentID, &comment.CreatedAt, &comment.EditedAt, &deletedAt, &rootID, &threadSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		replies = append(replies, visibleComment(comment, deletedAt))
+		threadSizes[rootID] = threadSize
+	}
+	return replies, threadSizes, rows.Err()
+}
+
+// GetCommentThread pages through every reply below the comment, at any depth,
+// in the order they were written
+func (r *postRepository) GetCommentThread(ctx context.Context, commentID int64, limit, offset int) (resp model.GetCommentsResponse, err error) {
+	thread := `WITH RECURSIVE thread AS (
+		SELECT id FROM comments WHERE parent_comment_id = ?
+		UNION ALL
+		SELECT r.id FROM comments r JOIN thread t ON r.parent_comment_id = t.id
+	) `
+	where := `
+	WHERE c.id IN (SELECT id FROM thread) AND ` + commentVisibleCondition
+
+	var total int
+	err = r.db.QueryRowContext(ctx, thread+`SELECT COUNT(*) FROM comments c`+where, commentID).Scan(&total)
+	if err != nil {
+		return
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, postID)
+	data, err := r.queryComments(ctx, thread+commentQuery+where+`
+	ORDER BY c.created_at, c.id LIMIT ? OFFSET ?`, commentID, limit, offset)
 	if err != nil {
 		return
 	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+// GetPendingComments lists the comments of the post awaiting approval, oldest first
+func (r *postRepository) GetPendingComments(ctx context.Context, postID int64) (comments []model.CommentResponse, err error) {
+	query := commentQuery + `
+	WHERE c.post_id = ? AND c.status = 'pending' AND c.deleted_at IS NULL
+	ORDER BY c.created_at, c.id`
+
+	return r.queryComments(ctx, query, postID)
+}
+
+// queryComments runs a comment query selecting the commentQuery columns
+func (r *postRepository) queryComments(ctx context.Context, query string, args ...interface{}) (comments []model.CommentResponse, err error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
+	comments = []model.CommentResponse{}
 	for rows.Next() {
 		var (
-			comment  model.CommentResponse
-			username string
+			comment   model.CommentResponse
+			deletedAt *time.Time
 		)
-		err = rows.Scan(&comment.ID, &comment.UserID, &comment.CommentContent, &username)
+		err = rows.Scan(&comment.ID, &comment.UserID, &comment.Username, &comment.CommentContent, &comment.ParentCommentID, &comment.CreatedAt, &comment.EditedAt, &deletedAt)
 		if err != nil {
 			return nil, err
 		}
-		comments = append(comments, model.CommentResponse{
-			ID:             comment.ID,
-			UserID:         comment.UserID,
-			CommentContent: comment.CommentContent,
-			Username:       username,
-		})
+		comments = append(comments, visibleComment(comment, deletedAt))
+	}
+	return comments, rows.Err()
+}
+
+// visibleComment strips the author and content of a deleted comment
+func visibleComment(comment model.CommentResponse, deletedAt *time.Time) model.CommentResponse {
+	if deletedAt == nil {
+		return comment
+	}
+	return model.CommentResponse{
+		ID:              comment.ID,
+		CommentContent:  model.DeletedCommentPlaceholder,
+		CreatedAt:       comment.CreatedAt,
+		IsDeleted:       true,
+		ParentCommentID: comment.ParentCommentID,
+	}
+}
+
+func (r *postRepository) GetComment(ctx context.Context, id int64) (comment model.Comment, err error) {
+	query := `SELECT id, post_id, user_id, parent_comment_id, comment_content, status, edited_at, created_at, updated_at, created_by, updated_by, deleted_at, deleted_by FROM comments WHERE id = ?`
+
+	row := r.db.QueryRowContext(ctx, query, id)
+	err = row.Scan(&comment.ID, &comment.PostID, &comment.UserID, &comment.ParentCommentID, &comment.CommentContent, &comment.Status, &comment.EditedAt, &comment.CreatedAt, &comment.UpdatedAt, &comment.CreatedBy, &comment.UpdatedBy, &comment.DeletedAt, &comment.DeletedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return comment, nil
+		}
+		return
 	}
 	return
 }
+
+// UpdateComment saves the edited content of the comment with its moderation status
+func (r *postRepository) UpdateComment(ctx context.Context, model model.Comment) (err error) {
+	query := `UPDATE comments SET comment_content = ?, status = ?, edited_at = ?, updated_at = ?, updated_by = ? WHERE id = ? AND deleted_at IS NULL`
+	_, err = r.db.ExecContext(ctx, query, model.CommentContent, model.Status, model.EditedAt, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteComment moves the comment to the trash
+func (r *postRepository) DeleteComment(ctx context.Context, model model.Comment) (err error) {
+	query := `UPDATE comments SET deleted_at = ?, deleted_by = ?, updated_at = ?, updated_by = ? WHERE id = ? AND deleted_at IS NULL`
+	_, err = r.db.ExecContext(ctx, query, model.DeletedAt, model.DeletedBy, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateCommentStatus records the moderation decision on the comment
+func (r *postRepository) UpdateCommentStatus(ctx context.Context, model model.Comment) (err error) {
+	query := `UPDATE comments SET status = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.Status, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
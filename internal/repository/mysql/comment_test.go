@@ -18,18 +18,20 @@ func TestCreateComment(t *testing.T) {
 	repo := &postRepository{db: db}
 
 	ctx := context.Background()
+	parentID := int64(5)
 	comment := model.Comment{
-		PostID:         1,
-		UserID:         2,
-		CommentContent: "This is a test comment",
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-		CreatedBy:      "test_user",
-		UpdatedBy:      "test_user",
+		PostID:          1,
+		UserID:          2,
+		ParentCommentID: &parentID,
+		CommentContent:  "This is a test comment",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		CreatedBy:       "test_user",
+		UpdatedBy:       "test_user",
 	}
 
 	mock.ExpectExec(`INSERT INTO comments`).
-		WithArgs(comment.PostID, comment.UserID, comment.CommentContent, comment.CreatedAt, comment.UpdatedAt, comment.CreatedBy, comment.UpdatedBy).
+		WithArgs(comment.PostID, comment.UserID, comment.ParentCommentID, comment.CommentContent, comment.Status, comment.CreatedAt, comment.UpdatedAt, comment.CreatedBy, comment.UpdatedBy).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	lastInsertID, err := repo.CreateComment(ctx, comment)
@@ -38,7 +40,7 @@ func TestCreateComment(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetCommentsByPostID(t *testing.T) {
+func TestGetComments(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
@@ -47,22 +49,234 @@ func TestGetCommentsByPostID(t *testing.T) {
 
 	ctx := context.Background()
 	postID := int64(1)
+	now := time.Now()
 
-	expectedComments := []model.CommentResponse{
-		{ID: 1, UserID: 2, CommentContent: "This is a test comment", Username: "test_user"},
-		{ID: 2, UserID: 3, CommentContent: "Another test comment", Username: "another_user"},
+	t.Run("First Page", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM comments c WHERE c.post_id = \? AND c.parent_comment_id IS NULL`).
+			WithArgs(postID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "username", "comment_content", "parent_comment_id", "created_at", "edited_at", "deleted_at", "reply_count"}).
+			AddRow(1, 2, "test_user", "This is a test comment", nil, now, nil, nil, 2).
+			AddRow(2, 3, "another_user", "Another test comment", nil, now, now, now, 1).
+			AddRow(3, 2, "test_user", "Third comment", nil, now, nil, nil, 0)
+
+		mock.ExpectQuery(`SELECT c.id, c.user_id, u.username, c.comment_content, c.parent_comment_id, c.created_at, c.edited_at, c.deleted_at, COALESCE\(rc.reply_count, 0\) FROM comments c JOIN users u ON c.user_id = u.id LEFT JOIN .* WHERE c.post_id = \? AND c.parent_comment_id IS NULL AND .* ORDER BY c.created_at ASC, c.id ASC LIMIT \?$`).
+			WithArgs(postID, postID, 3).
+			WillReturnRows(rows)
+
+		resp, err := repo.GetComments(ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, 2, resp.Data[0].ReplyCount)
+		assert.True(t, resp.Data[1].IsDeleted)
+		assert.Equal(t, model.DeletedCommentPlaceholder, resp.Data[1].CommentContent)
+		assert.Zero(t, resp.Data[1].UserID)
+		assert.Equal(t, model.Pagination{Limit: 2, Total: 3, HasMore: true}, resp.Pagination)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Top With Cursor", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM comments c`).
+			WithArgs(postID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		rows := sqlmock.NewRows([]string{"id", "user_id", "username", "comment_content", "parent_comment_id", "created_at", "edited_at", "deleted_at", "reply_count"}).
+			AddRow(3, 2, "test_user", "Third comment", nil, now, nil, nil, 0)
+
+		mock.ExpectQuery(`AND \(COALESCE\(rc.reply_count, 0\) < \? OR \(COALESCE\(rc.reply_count, 0\) = \? AND c.id < \?\)\) ORDER BY COALESCE\(rc.reply_count, 0\) DESC, c.id DESC LIMIT \?$`).
+			WithArgs(postID, postID, int64(1), int64(1), int64(2), 3).
+			WillReturnRows(rows)
+
+		cursor := &model.Cursor{Sort: string(model.CommentSortTop), Count: 1, ID: 2}
+		resp, err := repo.GetComments(ctx, postID, model.CommentSortTop, model.PageQuery{Limit: 2, Cursor: cursor})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.False(t, resp.Pagination.HasMore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetCommentReplies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+	parentID := int64(1)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "username", "comment_content", "parent_comment_id", "created_at", "edited_at", "deleted_at", "root_id", "thread_size"}).
+		AddRow(4, 3, "another_user", "A reply", parentID, now, now, nil, 1, 12)
+
+	mock.ExpectQuery(`WITH RECURSIVE thread AS \( SELECT id, parent_comment_id AS root_id FROM comments WHERE parent_comment_id IN \(\?, \?\) UNION ALL .* ROW_NUMBER\(\) OVER \(PARTITION BY t.root_id ORDER BY c.created_at, c.id\) AS position, COUNT\(\*\) OVER \(PARTITION BY t.root_id\) AS thread_size .* WHERE position <= \? ORDER BY created_at, id`).
+		WithArgs(int64(1), int64(2), 10).
+		WillReturnRows(rows)
+
+	replies, threadSizes, err := repo.GetCommentReplies(ctx, []int64{1, 2}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CommentResponse{
+		{ID: 4, UserID: 3, Username: "another_user", CommentContent: "A reply", ParentCommentID: &parentID, CreatedAt: now, EditedAt: &now},
+	}, replies)
+	assert.Equal(t, map[int64]int{1: 12}, threadSizes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCommentThread(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+	commentID := int64(1)
+
+	mock.ExpectQuery(`WITH RECURSIVE thread AS \( SELECT id FROM comments WHERE parent_comment_id = \? .* SELECT COUNT\(\*\) FROM comments c WHERE c.id IN \(SELECT id FROM thread\) AND c.status = 'approved' AND \(c.deleted_at IS NULL OR EXISTS \(WITH RECURSIVE descendants AS \(.* JOIN descendants d ON r.parent_comment_id = d.id .*\) SELECT 1 FROM descendants WHERE deleted_at IS NULL\)\)$`).
+		WithArgs(commentID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "username", "comment_content", "parent_comment_id", "created_at", "edited_at", "deleted_at"}).
+		AddRow(5, 3, "another_user", "A late reply", commentID, now, nil, nil)
+
+	mock.ExpectQuery(`WHERE c.id IN \(SELECT id FROM thread\) AND .* ORDER BY c.created_at, c.id LIMIT \? OFFSET \?`).
+		WithArgs(commentID, 2, 2).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetCommentThread(ctx, commentID, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CommentResponse{
+		{ID: 5, UserID: 3, Username: "another_user", CommentContent: "A late reply", ParentCommentID: &commentID, CreatedAt: now},
+	}, resp.Data)
+	assert.Equal(t, model.Pagination{Limit: 2, Offset: 2, Total: 3, HasMore: false}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	commentID := int64(1)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "post_id", "user_id", "parent_comment_id", "comment_content", "status", "edited_at", "created_at", "updated_at", "created_by", "updated_by", "deleted_at", "deleted_by"}).
+		AddRow(commentID, 1, 2, nil, "This is a test comment", "approved", nil, now, now, "2", "2", now, 2)
+
+	mock.ExpectQuery(`SELECT id, post_id, user_id, parent_comment_id, comment_content, status, edited_at, created_at, updated_at, created_by, updated_by, deleted_at, deleted_by FROM comments WHERE id = \?`).
+		WithArgs(commentID).
+		WillReturnRows(rows)
+
+	comment, err := repo.GetComment(ctx, commentID)
+	assert.NoError(t, err)
+	assert.Equal(t, commentID, comment.ID)
+	assert.Nil(t, comment.ParentCommentID)
+	assert.NotNil(t, comment.DeletedAt)
+	assert.Equal(t, int64(2), *comment.DeletedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+	comment := model.Comment{
+		ID:             1,
+		CommentContent: "Edited comment",
+		Status:         model.CommentStatusApproved,
+		EditedAt:       &now,
+		UpdatedAt:      now,
+		UpdatedBy:      "2",
+	}
+
+	mock.ExpectExec(`UPDATE comments SET comment_content = \?, status = \?, edited_at = \?, updated_at = \?, updated_by = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(comment.CommentContent, comment.Status, comment.EditedAt, comment.UpdatedAt, comment.UpdatedBy, comment.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateComment(ctx, comment)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+	deletedBy := int64(2)
+	comment := model.Comment{
+		ID:        1,
+		UpdatedAt: now,
+		UpdatedBy: "2",
+		DeletedAt: &now,
+		DeletedBy: &deletedBy,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "comment_content", "username"}).
-		AddRow(expectedComments[0].ID, expectedComments[0].UserID, expectedComments[0].CommentContent, expectedComments[0].Username).
-		AddRow(expectedComments[1].ID, expectedComments[1].UserID, expectedComments[1].CommentContent, expectedComments[1].Username)
+	mock.ExpectExec(`UPDATE comments SET deleted_at = \?, deleted_by = \?, updated_at = \?, updated_by = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(comment.DeletedAt, comment.DeletedBy, comment.UpdatedAt, comment.UpdatedBy, comment.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.DeleteComment(ctx, comment)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPendingComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	postID := int64(1)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "username", "comment_content", "parent_comment_id", "created_at", "edited_at", "deleted_at"}).
+		AddRow(4, 3, "another_user", "Waiting for approval", nil, now, nil, nil)
 
-	mock.ExpectQuery(`SELECT c.id, c.user_id, c.comment_content, u.username FROM comments c JOIN users u ON c.user_id = u.id WHERE c.post_id = \?`).
+	mock.ExpectQuery(`SELECT c.id, c.user_id, u.username, c.comment_content, c.parent_comment_id, c.created_at, c.edited_at, c.deleted_at FROM comments c JOIN users u ON c.user_id = u.id WHERE c.post_id = \? AND c.status = 'pending' AND c.deleted_at IS NULL ORDER BY c.created_at, c.id`).
 		WithArgs(postID).
 		WillReturnRows(rows)
 
-	comments, err := repo.GetCommentsByPostID(ctx, postID)
+	comments, err := repo.GetPendingComments(ctx, postID)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CommentResponse{
+		{ID: 4, UserID: 3, Username: "another_user", CommentContent: "Waiting for approval", CreatedAt: now},
+	}, comments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCommentStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	comment := model.Comment{ID: 1, Status: model.CommentStatusApproved, UpdatedAt: time.Now(), UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE comments SET status = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(comment.Status, comment.UpdatedAt, comment.UpdatedBy, comment.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateCommentStatus(ctx, comment)
 	assert.NoError(t, err)
-	assert.Equal(t, expectedComments, comments)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
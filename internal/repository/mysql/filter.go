@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// recentContentLimit bounds how many recent submissions duplicates are looked for in
+const recentContentLimit = 100
+
+// GetRecentContent lists what the user posted or commented since the given time,
+// newest first, other than the excluded post or comment. Posts are returned as
+// their title and content on separate lines.
+func (r *filterRepository) GetRecentContent(ctx context.Context, userID int64, kind model.ContentKind, since time.Time, excludeID int64) (texts []string, err error) {
+	query := `SELECT CONCAT(post_title, '\n', post_content) FROM posts WHERE user_id = ? AND created_at >= ? AND id <> ? ORDER BY created_at DESC LIMIT ?`
+	if kind == model.ContentKindComment {
+		query = `SELECT comment_content FROM comments WHERE user_id = ? AND created_at >= ? AND id <> ? ORDER BY created_at DESC LIMIT ?`
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since, excludeID, recentContentLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	texts = []string{}
+	for rows.Next() {
+		var text string
+		err = rows.Scan(&text)
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, rows.Err()
+}
+
+func (r *filterRepository) GetClassifierState(ctx context.Context) (state model.ClassifierState, err error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT label, doc_count FROM classifier_documents`)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			label string
+			count int
+		)
+		err = rows.Scan(&label, &count)
+		if err != nil {
+			return
+		}
+		if label == "spam" {
+			state.SpamDocs = count
+		} else {
+			state.HamDocs = count
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	tokenRows, err := r.db.QueryContext(ctx, `SELECT token, spam_count, ham_count FROM classifier_tokens`)
+	if err != nil {
+		return
+	}
+	defer tokenRows.Close()
+
+	state.Tokens = []model.ClassifierToken{}
+	for tokenRows.Next() {
+		var token model.ClassifierToken
+		err = tokenRows.Scan(&token.Token, &token.SpamCount, &token.HamCount)
+		if err != nil {
+			return
+		}
+		state.Tokens = append(state.Tokens, token)
+	}
+	err = tokenRows.Err()
+	return
+}
+
+// AddClassifierSample counts one more spam or ham document and its tokens
+func (r *filterRepository) AddClassifierSample(ctx context.Context, tokens []string, spam bool) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	label, column := "ham", "ham_count"
+	if spam {
+		label, column = "spam", "spam_count"
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE classifier_documents SET doc_count = doc_count + 1 WHERE label = ?`, label)
+	if err != nil {
+		return
+	}
+
+	if len(tokens) > 0 {
+		values := strings.TrimSuffix(strings.Repeat("(?, 1), ", len(tokens)), ", ")
+		args := make([]interface{}, 0, len(tokens))
+		for _, token := range tokens {
+			args = append(args, token)
+		}
+
+		query := `INSERT INTO classifier_tokens (token, ` + column + `) VALUES ` + values + `
+		ON DUPLICATE KEY UPDATE ` + column + ` = ` + column + ` + 1`
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+	return
+}
@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetRecentContent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &filterRepository{db: db}
+
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	t.Run("Posts", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT CONCAT\(post_title, '\\n', post_content\) FROM posts WHERE user_id = \? AND created_at >= \? AND id <> \? ORDER BY created_at DESC LIMIT \?`).
+			WithArgs(int64(1), since, int64(0), recentContentLimit).
+			WillReturnRows(sqlmock.NewRows([]string{"text"}).AddRow("Title\nContent"))
+
+		texts, err := repo.GetRecentContent(ctx, 1, model.ContentKindPost, since, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Title\nContent"}, texts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Comments", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT comment_content FROM comments WHERE user_id = \? AND created_at >= \? AND id <> \? ORDER BY created_at DESC LIMIT \?`).
+			WithArgs(int64(1), since, int64(7), recentContentLimit).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_content"}).AddRow("first").AddRow("second"))
+
+		texts, err := repo.GetRecentContent(ctx, 1, model.ContentKindComment, since, 7)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, texts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetClassifierState(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &filterRepository{db: db}
+
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT label, doc_count FROM classifier_documents`).
+		WillReturnRows(sqlmock.NewRows([]string{"label", "doc_count"}).AddRow("spam", 3).AddRow("ham", 7))
+	mock.ExpectQuery(`SELECT token, spam_count, ham_count FROM classifier_tokens`).
+		WillReturnRows(sqlmock.NewRows([]string{"token", "spam_count", "ham_count"}).AddRow("casino", 3, 0).AddRow("article", 0, 5))
+
+	state, err := repo.GetClassifierState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ClassifierState{
+		SpamDocs: 3,
+		HamDocs:  7,
+		Tokens: []model.ClassifierToken{
+			{Token: "casino", SpamCount: 3},
+			{Token: "article", HamCount: 5},
+		},
+	}, state)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddClassifierSample(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &filterRepository{db: db}
+
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE classifier_documents SET doc_count = doc_count \+ 1 WHERE label = \?`).
+			WithArgs("spam").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO classifier_tokens \(token, spam_count\) VALUES \(\?, 1\), \(\?, 1\) ON DUPLICATE KEY UPDATE spam_count = spam_count \+ 1`).
+			WithArgs("cheap", "pills").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.AddClassifierSample(ctx, []string{"cheap", "pills"}, true)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback On Error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE classifier_documents`).
+			WithArgs("ham").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO classifier_tokens \(token, ham_count\)`).
+			WithArgs("thanks").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.AddClassifierSample(ctx, []string{"thanks"}, false)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// CreateFollow makes the follower follow the followee, following twice is a no-op
+func (r *followRepository) CreateFollow(ctx context.Context, model model.Follow) (err error) {
+	query := `INSERT IGNORE INTO follows (follower_id, followee_id, created_at) VALUES (?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, model.FollowerID, model.FolloweeID, model.CreatedAt)
+	return err
+}
+
+// HasBlock reports whether either user blocks the other
+func (r *followRepository) HasBlock(ctx context.Context, userID, otherID int64) (blocked bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM blocks WHERE (blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?))`
+	err = r.db.QueryRowContext(ctx, query, userID, otherID, otherID, userID).Scan(&blocked)
+	return
+}
+
+// DeleteFollow stops the follow, reporting whether there was one
+func (r *followRepository) DeleteFollow(ctx context.Context, followerID, followeeID int64) (deleted bool, err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM follows WHERE follower_id = ? AND followee_id = ?`, followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetFollowers lists the users following the user, most recent follow first
+func (r *followRepository) GetFollowers(ctx context.Context, userID int64, limit, offset int) (resp model.GetFollowsResponse, err error) {
+	return r.getFollows(ctx, "followee_id", "follower_id", userID, limit, offset)
+}
+
+// GetFollowing lists the users followed by the user, most recent follow first
+func (r *followRepository) GetFollowing(ctx context.Context, userID int64, limit, offset int) (resp model.GetFollowsResponse, err error) {
+	return r.getFollows(ctx, "follower_id", "followee_id", userID, limit, offset)
+}
+
+// getFollows pages through the follows whose by column is the user, listing
+// the users of the other column. Both columns are fixed by the callers.
+func (r *followRepository) getFollows(ctx context.Context, by, other string, userID int64, limit, offset int) (resp model.GetFollowsResponse, err error) {
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM follows WHERE `+by+` = ?`, userID).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := `SELECT u.id, u.username, f.created_at FROM follows f JOIN users u ON u.id = f.` + other + `
+	WHERE f.` + by + ` = ? ORDER BY f.created_at DESC, u.id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.FollowUser{}
+	for rows.Next() {
+		var user model.FollowUser
+		if err = rows.Scan(&user.UserID, &user.Username, &user.FollowedAt); err != nil {
+			return
+		}
+		data = append(data, user)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+func (r *followRepository) GetFollowCounts(ctx context.Context, userID, viewerID int64) (counts model.FollowCounts, err error) {
+	query := `SELECT
+		(SELECT COUNT(*) FROM follows WHERE followee_id = ?),
+		(SELECT COUNT(*) FROM follows WHERE follower_id = ?),
+		EXISTS (SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = ?)`
+	err = r.db.QueryRowContext(ctx, query, userID, userID, viewerID, userID).Scan(&counts.FollowerCount, &counts.FollowingCount, &counts.IsFollowing)
+	return
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestCreateFollow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+	now := time.Now()
+
+	mock.ExpectExec(`INSERT IGNORE INTO follows \(follower_id, followee_id, created_at\) VALUES \(\?, \?, \?\)`).
+		WithArgs(int64(1), int64(2), now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.CreateFollow(context.Background(), model.Follow{FollowerID: 1, FolloweeID: 2, CreatedAt: now})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHasBlock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM blocks WHERE \(blocker_id = \? AND blocked_id = \?\) OR \(blocker_id = \? AND blocked_id = \?\)\)`).
+		WithArgs(int64(1), int64(2), int64(2), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"blocked"}).AddRow(true))
+
+	blocked, err := repo.HasBlock(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFollow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+
+	mock.ExpectExec(`DELETE FROM follows WHERE follower_id = \? AND followee_id = \?`).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	deleted, err := repo.DeleteFollow(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFollowers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM follows WHERE followee_id = \?`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT u.id, u.username, f.created_at FROM follows f JOIN users u ON u.id = f.follower_id WHERE f.followee_id = \? ORDER BY f.created_at DESC, u.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(int64(2), 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at"}).
+			AddRow(5, "alice", now).
+			AddRow(4, "bob", now))
+
+	resp, err := repo.GetFollowers(context.Background(), 2, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.FollowUser{{UserID: 5, Username: "alice", FollowedAt: now}, {UserID: 4, Username: "bob", FollowedAt: now}}, resp.Data)
+	assert.Equal(t, model.Pagination{Limit: 2, Offset: 0, Total: 3, HasMore: true}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFollowing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM follows WHERE follower_id = \?`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT u.id, u.username, f.created_at FROM follows f JOIN users u ON u.id = f.followee_id WHERE f.follower_id = \?`).
+		WithArgs(int64(2), 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at"}))
+
+	resp, err := repo.GetFollowing(context.Background(), 2, 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFollowCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &followRepository{db: db}
+
+	mock.ExpectQuery(`SELECT \(SELECT COUNT\(\*\) FROM follows WHERE followee_id = \?\), \(SELECT COUNT\(\*\) FROM follows WHERE follower_id = \?\), EXISTS \(SELECT 1 FROM follows WHERE follower_id = \? AND followee_id = \?\)`).
+		WithArgs(int64(2), int64(2), int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"followers", "following", "is_following"}).AddRow(7, 3, true))
+
+	counts, err := repo.GetFollowCounts(context.Background(), 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.FollowCounts{FollowerCount: 7, FollowingCount: 3, IsFollowing: true}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
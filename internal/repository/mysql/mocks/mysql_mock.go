@@ -18,6 +18,11 @@ func (m *MockUserRepository) GetUser(ctx context.Context, email, username string
 	return args.Get(0).(model.User), args.Error(1)
 }
 
+func (m *MockUserRepository) IsUserActive(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) CreateUser(ctx context.Context, user model.User) (int64, error) {
 	args := m.Called(ctx, user)
 	return args.Get(0).(int64), args.Error(1)
@@ -28,6 +33,61 @@ func (m *MockUserRepository) GetRefreshToken(ctx context.Context, userID int64,
 	return args.Get(0).(model.RefreshToken), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserProfile(ctx context.Context, userID int64, username string, viewerID int64) (model.UserProfile, error) {
+	args := m.Called(ctx, userID, username, viewerID)
+	return args.Get(0).(model.UserProfile), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserProfile(ctx context.Context, user model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, user model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreatePasswordReset(ctx context.Context, reset model.PasswordReset) error {
+	args := m.Called(ctx, reset)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ResetPassword(ctx context.Context, tokenHash, password string, now time.Time) (int64, error) {
+	args := m.Called(ctx, tokenHash, password, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateEmailVerification(ctx context.Context, verification model.EmailVerification) error {
+	args := m.Called(ctx, verification)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetLatestEmailVerification(ctx context.Context, userID int64) (model.EmailVerification, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.EmailVerification), args.Error(1)
+}
+
+func (m *MockUserRepository) VerifyEmail(ctx context.Context, tokenHash string, now time.Time) (int64, error) {
+	args := m.Called(ctx, tokenHash, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserExport(ctx context.Context, userID int64) (model.UserExport, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.UserExport), args.Error(1)
+}
+
+func (m *MockUserRepository) AnonymizeUser(ctx context.Context, userID int64, now time.Time) error {
+	args := m.Called(ctx, userID, now)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) InsertRefreshToken(ctx context.Context, token model.RefreshToken) (int64, error) {
 	args := m.Called(ctx, token)
 	return args.Get(0).(int64), args.Error(1)
@@ -38,28 +98,123 @@ type MockPostRepository struct {
 	mock.Mock
 }
 
-func (m *MockPostRepository) CreatePost(ctx context.Context, post model.Post) (int64, error) {
-	args := m.Called(ctx, post)
+func (m *MockPostRepository) CreatePost(ctx context.Context, post model.Post, revision model.PostRevision) (int64, error) {
+	args := m.Called(ctx, post, revision)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockPostRepository) GetPostByID(ctx context.Context, postID int64) (model.PostDetail, error) {
-	args := m.Called(ctx, postID)
+func (m *MockPostRepository) GetPostByID(ctx context.Context, postID, viewerID int64) (model.PostDetail, error) {
+	args := m.Called(ctx, postID, viewerID)
 	return args.Get(0).(model.PostDetail), args.Error(1)
 }
 
-func (m *MockPostRepository) CountLikeByPostID(ctx context.Context, postID int64) (int, error) {
+func (m *MockPostRepository) GetPost(ctx context.Context, postID int64) (model.Post, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(model.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdatePost(ctx context.Context, post model.Post, revisions []model.PostRevision) error {
+	args := m.Called(ctx, post, revisions)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) UpdatePostStatus(ctx context.Context, post model.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetPendingPosts(ctx context.Context, viewerID int64, limit, offset int) (model.GetAllPostResponse, error) {
+	args := m.Called(ctx, viewerID, limit, offset)
+	return args.Get(0).(model.GetAllPostResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) PublishDuePosts(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostRepository) IncrementViewCounts(ctx context.Context, counts map[int64]int64, at time.Time) error {
+	args := m.Called(ctx, counts, at)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) DeletePost(ctx context.Context, post model.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) RestorePost(ctx context.Context, post model.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetTags(ctx context.Context, limit, offset int) (model.GetTagsResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).(model.GetTagsResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) CountPostRevisions(ctx context.Context, postID int64) (int, error) {
 	args := m.Called(ctx, postID)
 	return args.Get(0).(int), args.Error(1)
 }
 
-func (m *MockPostRepository) GetCommentsByPostID(ctx context.Context, postID int64) ([]model.CommentResponse, error) {
+func (m *MockPostRepository) GetPostRevisions(ctx context.Context, postID int64) ([]model.PostRevisionSummary, error) {
 	args := m.Called(ctx, postID)
-	return args.Get(0).([]model.CommentResponse), args.Error(1)
+	return args.Get(0).([]model.PostRevisionSummary), args.Error(1)
 }
 
-func (m *MockPostRepository) GetAllPost(ctx context.Context, limit, offset int) (model.GetAllPostResponse, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockPostRepository) GetPostRevision(ctx context.Context, postID int64, revision int) (model.PostRevision, error) {
+	args := m.Called(ctx, postID, revision)
+	return args.Get(0).(model.PostRevision), args.Error(1)
+}
+
+func (m *MockPostRepository) GetPreviousPostRevision(ctx context.Context, postID int64, revision int) (model.PostRevision, error) {
+	args := m.Called(ctx, postID, revision)
+	return args.Get(0).(model.PostRevision), args.Error(1)
+}
+
+func (m *MockPostRepository) GetComment(ctx context.Context, commentID int64) (model.Comment, error) {
+	args := m.Called(ctx, commentID)
+	return args.Get(0).(model.Comment), args.Error(1)
+}
+
+func (m *MockPostRepository) RestoreComment(ctx context.Context, comment model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetTrashedPosts(ctx context.Context, userID int64) ([]model.TrashedPost, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.TrashedPost), args.Error(1)
+}
+
+func (m *MockPostRepository) GetTrashedComments(ctx context.Context, userID int64) ([]model.TrashedComment, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.TrashedComment), args.Error(1)
+}
+
+func (m *MockPostRepository) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostRepository) GetComments(ctx context.Context, postID int64, sort model.CommentSort, page model.PageQuery) (model.GetCommentsResponse, error) {
+	args := m.Called(ctx, postID, sort, page)
+	return args.Get(0).(model.GetCommentsResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) GetCommentReplies(ctx context.Context, commentIDs []int64, limit int) ([]model.CommentResponse, map[int64]int, error) {
+	args := m.Called(ctx, commentIDs, limit)
+	return args.Get(0).([]model.CommentResponse), args.Get(1).(map[int64]int), args.Error(2)
+}
+
+func (m *MockPostRepository) GetCommentThread(ctx context.Context, commentID int64, limit, offset int) (model.GetCommentsResponse, error) {
+	args := m.Called(ctx, commentID, limit, offset)
+	return args.Get(0).(model.GetCommentsResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) GetAllPost(ctx context.Context, viewerID int64, filter model.PostFilter, page model.PageQuery) (model.GetAllPostResponse, error) {
+	args := m.Called(ctx, viewerID, filter, page)
 	return args.Get(0).(model.GetAllPostResponse), args.Error(1)
 }
 
@@ -82,3 +237,178 @@ func (m *MockPostRepository) UpdateUserActivity(ctx context.Context, activity mo
 	args := m.Called(ctx, activity)
 	return args.Error(0)
 }
+
+func (m *MockPostRepository) GetPostReactions(ctx context.Context, postID, viewerID int64) (map[string]int, string, error) {
+	args := m.Called(ctx, postID, viewerID)
+	return args.Get(0).(map[string]int), args.String(1), args.Error(2)
+}
+
+func (m *MockPostRepository) GetPostLikes(ctx context.Context, postID int64, limit, offset int) (model.GetPostLikesResponse, error) {
+	args := m.Called(ctx, postID, limit, offset)
+	return args.Get(0).(model.GetPostLikesResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) GetLikedPosts(ctx context.Context, userID, viewerID int64, limit, offset int) (model.GetAllPostResponse, error) {
+	args := m.Called(ctx, userID, viewerID, limit, offset)
+	return args.Get(0).(model.GetAllPostResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) IsBlocked(ctx context.Context, blockerID, blockedID int64) (bool, error) {
+	args := m.Called(ctx, blockerID, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdateComment(ctx context.Context, comment model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) DeleteComment(ctx context.Context, comment model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) UpdatePostCommentMode(ctx context.Context, post model.Post) error {
+	args := m.Called(ctx, post)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetPendingComments(ctx context.Context, postID int64) ([]model.CommentResponse, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).([]model.CommentResponse), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdateCommentStatus(ctx context.Context, comment model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+// Mock bookmark repository
+type MockBookmarkRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookmarkRepository) UpsertBookmark(ctx context.Context, bookmark model.Bookmark) error {
+	args := m.Called(ctx, bookmark)
+	return args.Error(0)
+}
+
+func (m *MockBookmarkRepository) DeleteBookmark(ctx context.Context, userID, postID int64) (bool, error) {
+	args := m.Called(ctx, userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) GetBookmarks(ctx context.Context, userID int64, collection *string, limit, offset int) (model.GetBookmarksResponse, error) {
+	args := m.Called(ctx, userID, collection, limit, offset)
+	return args.Get(0).(model.GetBookmarksResponse), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) GetBookmarkCollections(ctx context.Context, userID int64) ([]model.BookmarkCollection, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.BookmarkCollection), args.Error(1)
+}
+
+// Mock trending repository
+type MockTrendingRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrendingRepository) GetEngagements(ctx context.Context, since time.Time) ([]model.Engagement, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]model.Engagement), args.Error(1)
+}
+
+func (m *MockTrendingRepository) GetPostTags(ctx context.Context, postIDs []int64) (map[int64][]string, error) {
+	args := m.Called(ctx, postIDs)
+	return args.Get(0).(map[int64][]string), args.Error(1)
+}
+
+func (m *MockTrendingRepository) ReplaceTrending(ctx context.Context, posts []model.TrendingPost, tags []model.TrendingTag, computedAt time.Time) error {
+	args := m.Called(ctx, posts, tags, computedAt)
+	return args.Error(0)
+}
+
+func (m *MockTrendingRepository) PruneViewBuckets(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTrendingRepository) GetTrendingPosts(ctx context.Context, viewerID int64, limit, offset int) (model.GetAllPostResponse, error) {
+	args := m.Called(ctx, viewerID, limit, offset)
+	return args.Get(0).(model.GetAllPostResponse), args.Error(1)
+}
+
+func (m *MockTrendingRepository) GetTrendingTags(ctx context.Context, limit, offset int) (model.GetTrendingTagsResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).(model.GetTrendingTagsResponse), args.Error(1)
+}
+
+// Mock follow repository
+type MockFollowRepository struct {
+	mock.Mock
+}
+
+func (m *MockFollowRepository) CreateFollow(ctx context.Context, follow model.Follow) error {
+	args := m.Called(ctx, follow)
+	return args.Error(0)
+}
+
+func (m *MockFollowRepository) HasBlock(ctx context.Context, userID, otherID int64) (bool, error) {
+	args := m.Called(ctx, userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) DeleteFollow(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowers(ctx context.Context, userID int64, limit, offset int) (model.GetFollowsResponse, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	return args.Get(0).(model.GetFollowsResponse), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowing(ctx context.Context, userID int64, limit, offset int) (model.GetFollowsResponse, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	return args.Get(0).(model.GetFollowsResponse), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowCounts(ctx context.Context, userID, viewerID int64) (model.FollowCounts, error) {
+	args := m.Called(ctx, userID, viewerID)
+	return args.Get(0).(model.FollowCounts), args.Error(1)
+}
+
+// Mock block repository
+type MockBlockRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlockRepository) CreateBlock(ctx context.Context, block model.Block) error {
+	args := m.Called(ctx, block)
+	return args.Error(0)
+}
+
+func (m *MockBlockRepository) DeleteBlock(ctx context.Context, blockerID, blockedID int64) (bool, error) {
+	args := m.Called(ctx, blockerID, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBlockRepository) GetBlockedUsers(ctx context.Context, blockerID int64, limit, offset int) (model.GetRestrictedUsersResponse, error) {
+	args := m.Called(ctx, blockerID, limit, offset)
+	return args.Get(0).(model.GetRestrictedUsersResponse), args.Error(1)
+}
+
+func (m *MockBlockRepository) CreateMute(ctx context.Context, mute model.Mute) error {
+	args := m.Called(ctx, mute)
+	return args.Error(0)
+}
+
+func (m *MockBlockRepository) DeleteMute(ctx context.Context, muterID, mutedID int64) (bool, error) {
+	args := m.Called(ctx, muterID, mutedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBlockRepository) GetMutedUsers(ctx context.Context, muterID int64, limit, offset int) (model.GetRestrictedUsersResponse, error) {
+	args := m.Called(ctx, muterID, limit, offset)
+	return args.Get(0).(model.GetRestrictedUsersResponse), args.Error(1)
+}
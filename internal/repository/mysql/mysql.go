@@ -10,9 +10,21 @@ import (
 
 type UserRepository interface {
 	GetUser(ctx context.Context, email, username string, userID int64) (user model.User, err error)
+	IsUserActive(ctx context.Context, userID int64) (active bool, err error)
 	CreateUser(ctx context.Context, model model.User) (lastInsertID int64, err error)
 	InsertRefreshToken(ctx context.Context, model model.RefreshToken) (lastInsertID int64, err error)
 	GetRefreshToken(ctx context.Context, userID int64, now time.Time) (resp model.RefreshToken, err error)
+	GetUserProfile(ctx context.Context, userID int64, username string, viewerID int64) (profile model.UserProfile, err error)
+	UpdateUserProfile(ctx context.Context, model model.User) (err error)
+	UpdatePassword(ctx context.Context, model model.User) (err error)
+	CreatePasswordReset(ctx context.Context, model model.PasswordReset) (err error)
+	ResetPassword(ctx context.Context, tokenHash, password string, now time.Time) (userID int64, err error)
+	CreateEmailVerification(ctx context.Context, model model.EmailVerification) (err error)
+	GetLatestEmailVerification(ctx context.Context, userID int64) (verification model.EmailVerification, err error)
+	VerifyEmail(ctx context.Context, tokenHash string, now time.Time) (userID int64, err error)
+	GetUserExport(ctx context.Context, userID int64) (export model.UserExport, err error)
+	AnonymizeUser(ctx context.Context, userID int64, now time.Time) (err error)
+	DeleteUser(ctx context.Context, userID int64) (err error)
 }
 
 type userRepository struct {
@@ -26,15 +38,43 @@ func NewUserRepository(db *sql.DB) UserRepository {
 }
 
 type PostRepository interface {
-	CreatePost(ctx context.Context, model model.Post) (lastInsertID int64, err error)
-	GetAllPost(ctx context.Context, limit, offset int) (resp model.GetAllPostResponse, err error)
-	GetPostByID(ctx context.Context, id int64) (resp model.PostDetail, err error)
+	CreatePost(ctx context.Context, model model.Post, revision model.PostRevision) (lastInsertID int64, err error)
+	GetAllPost(ctx context.Context, viewerID int64, filter model.PostFilter, page model.PageQuery) (resp model.GetAllPostResponse, err error)
+	GetPostByID(ctx context.Context, id, viewerID int64) (resp model.PostDetail, err error)
+	GetPost(ctx context.Context, id int64) (post model.Post, err error)
+	UpdatePost(ctx context.Context, model model.Post, revisions []model.PostRevision) (err error)
+	UpdatePostStatus(ctx context.Context, model model.Post) (err error)
+	GetPendingPosts(ctx context.Context, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error)
+	UpdatePostCommentMode(ctx context.Context, model model.Post) (err error)
+	PublishDuePosts(ctx context.Context, now time.Time) (published int64, err error)
+	IncrementViewCounts(ctx context.Context, counts map[int64]int64, at time.Time) (err error)
+	DeletePost(ctx context.Context, model model.Post) (err error)
+	RestorePost(ctx context.Context, model model.Post) (err error)
+	GetTags(ctx context.Context, limit, offset int) (resp model.GetTagsResponse, err error)
+	CountPostRevisions(ctx context.Context, postID int64) (count int, err error)
+	GetPostRevisions(ctx context.Context, postID int64) (revisions []model.PostRevisionSummary, err error)
+	GetPostRevision(ctx context.Context, postID int64, revision int) (resp model.PostRevision, err error)
+	GetPreviousPostRevision(ctx context.Context, postID int64, revision int) (resp model.PostRevision, err error)
 	CreateComment(ctx context.Context, model model.Comment) (lastInsertID int64, err error)
-	GetCommentsByPostID(ctx context.Context, postID int64) (comments []model.CommentResponse, err error)
+	GetComments(ctx context.Context, postID int64, sort model.CommentSort, page model.PageQuery) (resp model.GetCommentsResponse, err error)
+	GetCommentReplies(ctx context.Context, commentIDs []int64, limit int) (replies []model.CommentResponse, threadSizes map[int64]int, err error)
+	GetCommentThread(ctx context.Context, commentID int64, limit, offset int) (resp model.GetCommentsResponse, err error)
+	GetComment(ctx context.Context, id int64) (comment model.Comment, err error)
+	UpdateComment(ctx context.Context, model model.Comment) (err error)
+	GetPendingComments(ctx context.Context, postID int64) (comments []model.CommentResponse, err error)
+	UpdateCommentStatus(ctx context.Context, model model.Comment) (err error)
+	DeleteComment(ctx context.Context, model model.Comment) (err error)
+	RestoreComment(ctx context.Context, model model.Comment) (err error)
+	GetTrashedPosts(ctx context.Context, userID int64) (posts []model.TrashedPost, err error)
+	GetTrashedComments(ctx context.Context, userID int64) (comments []model.TrashedComment, err error)
+	PurgeTrash(ctx context.Context, before time.Time) (purged int64, err error)
 	GetUserActivity(ctx context.Context, model model.UserActivity) (resp model.UserActivity, err error)
 	CreateUserActivity(ctx context.Context, model model.UserActivity) (lastInsertID int64, err error)
 	UpdateUserActivity(ctx context.Context, req model.UserActivity) (err error)
-	CountLikeByPostID(ctx context.Context, postID int64) (count int, err error)
+	GetPostReactions(ctx context.Context, postID, viewerID int64) (counts map[string]int, viewerReaction string, err error)
+	GetPostLikes(ctx context.Context, postID int64, limit, offset int) (resp model.GetPostLikesResponse, err error)
+	GetLikedPosts(ctx context.Context, userID, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error)
+	IsBlocked(ctx context.Context, blockerID, blockedID int64) (blocked bool, err error)
 }
 
 type postRepository struct {
@@ -46,3 +86,116 @@ func NewPostRepository(db *sql.DB) PostRepository {
 		db: db,
 	}
 }
+
+// SearchRepository finds posts and comments matching a free text query,
+// ordered by relevance
+type SearchRepository interface {
+	Search(ctx context.Context, query model.SearchQuery) (hits []model.SearchHit, err error)
+}
+
+type searchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) SearchRepository {
+	return &searchRepository{
+		db: db,
+	}
+}
+
+// FilterRepository provides the data the content filters decide on: recent
+// submissions of a user and the state of the spam classifier
+type FilterRepository interface {
+	GetRecentContent(ctx context.Context, userID int64, kind model.ContentKind, since time.Time, excludeID int64) (texts []string, err error)
+	GetClassifierState(ctx context.Context) (state model.ClassifierState, err error)
+	AddClassifierSample(ctx context.Context, tokens []string, spam bool) (err error)
+}
+
+type filterRepository struct {
+	db *sql.DB
+}
+
+func NewFilterRepository(db *sql.DB) FilterRepository {
+	return &filterRepository{
+		db: db,
+	}
+}
+
+// BookmarkRepository stores the posts users saved to read later
+type BookmarkRepository interface {
+	UpsertBookmark(ctx context.Context, model model.Bookmark) (err error)
+	DeleteBookmark(ctx context.Context, userID, postID int64) (deleted bool, err error)
+	GetBookmarks(ctx context.Context, userID int64, collection *string, limit, offset int) (resp model.GetBookmarksResponse, err error)
+	GetBookmarkCollections(ctx context.Context, userID int64) (collections []model.BookmarkCollection, err error)
+}
+
+type bookmarkRepository struct {
+	db *sql.DB
+}
+
+func NewBookmarkRepository(db *sql.DB) BookmarkRepository {
+	return &bookmarkRepository{
+		db: db,
+	}
+}
+
+// TrendingRepository reads the recent engagement of posts and stores the
+// rankings computed from it
+type TrendingRepository interface {
+	GetEngagements(ctx context.Context, since time.Time) (engagements []model.Engagement, err error)
+	GetPostTags(ctx context.Context, postIDs []int64) (tags map[int64][]string, err error)
+	ReplaceTrending(ctx context.Context, posts []model.TrendingPost, tags []model.TrendingTag, computedAt time.Time) (err error)
+	PruneViewBuckets(ctx context.Context, before time.Time) (pruned int64, err error)
+	GetTrendingPosts(ctx context.Context, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error)
+	GetTrendingTags(ctx context.Context, limit, offset int) (resp model.GetTrendingTagsResponse, err error)
+}
+
+type trendingRepository struct {
+	db *sql.DB
+}
+
+func NewTrendingRepository(db *sql.DB) TrendingRepository {
+	return &trendingRepository{
+		db: db,
+	}
+}
+
+// FollowRepository stores who follows whom
+type FollowRepository interface {
+	CreateFollow(ctx context.Context, model model.Follow) (err error)
+	DeleteFollow(ctx context.Context, followerID, followeeID int64) (deleted bool, err error)
+	GetFollowers(ctx context.Context, userID int64, limit, offset int) (resp model.GetFollowsResponse, err error)
+	GetFollowing(ctx context.Context, userID int64, limit, offset int) (resp model.GetFollowsResponse, err error)
+	GetFollowCounts(ctx context.Context, userID, viewerID int64) (counts model.FollowCounts, err error)
+	HasBlock(ctx context.Context, userID, otherID int64) (blocked bool, err error)
+}
+
+type followRepository struct {
+	db *sql.DB
+}
+
+func NewFollowRepository(db *sql.DB) FollowRepository {
+	return &followRepository{
+		db: db,
+	}
+}
+
+// BlockRepository stores the users blocked and muted by each user
+type BlockRepository interface {
+	CreateBlock(ctx context.Context, model model.Block) (err error)
+	DeleteBlock(ctx context.Context, blockerID, blockedID int64) (deleted bool, err error)
+	GetBlockedUsers(ctx context.Context, blockerID int64, limit, offset int) (resp model.GetRestrictedUsersResponse, err error)
+	CreateMute(ctx context.Context, model model.Mute) (err error)
+	DeleteMute(ctx context.Context, muterID, mutedID int64) (deleted bool, err error)
+	GetMutedUsers(ctx context.Context, muterID int64, limit, offset int) (resp model.GetRestrictedUsersResponse, err error)
+}
+
+type blockRepository struct {
+	db *sql.DB
+}
+
+func NewBlockRepository(db *sql.DB) BlockRepository {
+	return &blockRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// UpdatePassword sets the password hash of the user and signs them out of
+// every session by deleting their refresh tokens. Pending reset tokens are
+// used up too.
+func (r *userRepository) UpdatePassword(ctx context.Context, model model.User) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = setPassword(ctx, tx, model.ID, model.Password, model.UpdatedAt, model.UpdatedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *userRepository) CreatePasswordReset(ctx context.Context, model model.PasswordReset) (err error) {
+	query := `INSERT INTO password_resets (user_id, token_hash, expired_at, created_at) VALUES (?, ?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, model.UserID, model.TokenHash, model.ExpiredAt, model.CreatedAt)
+	return err
+}
+
+// ResetPassword uses the unexpired reset token with the hash to set the
+// password of its user, the same way UpdatePassword does. It returns the id of
+// the user, or 0 when there is no such token.
+func (r *userRepository) ResetPassword(ctx context.Context, tokenHash, password string, now time.Time) (userID int64, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `SELECT user_id FROM password_resets WHERE token_hash = ? AND used_at IS NULL AND expired_at >= ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, tokenHash, now).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, tx.Rollback()
+		}
+		return 0, err
+	}
+
+	if err = setPassword(ctx, tx, userID, password, now, strconv.FormatInt(userID, 10)); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
+
+// setPassword changes the password hash and revokes the refresh tokens and
+// pending reset tokens of the user
+func setPassword(ctx context.Context, tx *sql.Tx, userID int64, password string, updatedAt time.Time, updatedBy string) (err error) {
+	query := `UPDATE users SET password = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	if _, err = tx.ExecContext(ctx, query, password, updatedAt, updatedBy, userID); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE password_resets SET used_at = ? WHERE user_id = ? AND used_at IS NULL`, updatedAt, userID)
+	return err
+}
@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	user := model.User{ID: 1, Password: "hash", UpdatedAt: now, UpdatedBy: "1"}
+
+	t.Run("Success UpdatePassword", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE users SET password = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+			WithArgs("hash", now, "1", int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM refresh_tokens WHERE user_id = \?`).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`UPDATE password_resets SET used_at = \? WHERE user_id = \? AND used_at IS NULL`).
+			WithArgs(now, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err = repo.UpdatePassword(ctx, user)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail UpdatePassword - Rolls Back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE users SET password = \?`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM refresh_tokens WHERE user_id = \?`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err = repo.UpdatePassword(ctx, user)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreatePasswordReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	now := time.Now()
+	reset := model.PasswordReset{UserID: 1, TokenHash: "abc", ExpiredAt: now.Add(time.Hour), CreatedAt: now}
+
+	mock.ExpectExec(`INSERT INTO password_resets \(user_id, token_hash, expired_at, created_at\) VALUES \(\?, \?, \?, \?\)`).
+		WithArgs(int64(1), "abc", reset.ExpiredAt, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.CreatePasswordReset(context.Background(), reset)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	selectToken := `SELECT user_id FROM password_resets WHERE token_hash = \? AND used_at IS NULL AND expired_at >= \? FOR UPDATE`
+
+	t.Run("Success ResetPassword", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectToken).
+			WithArgs("abc", now).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(7))
+		mock.ExpectExec(`UPDATE users SET password = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+			WithArgs("hash", now, "7", int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM refresh_tokens WHERE user_id = \?`).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE password_resets SET used_at = \? WHERE user_id = \? AND used_at IS NULL`).
+			WithArgs(now, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		userID, err := repo.ResetPassword(ctx, "abc", "hash", now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), userID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success ResetPassword - Unknown Token", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectToken).
+			WithArgs("abc", now).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		mock.ExpectRollback()
+
+		userID, err := repo.ResetPassword(ctx, "abc", "hash", now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), userID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,14 +2,26 @@ package mysql
 
 import (
 	"context"
-	"strings"
+	"database/sql"
+	"time"
 
 	"github.com/suhriar/blog-mono-api/model"
 )
 
-func (r *postRepository) CreatePost(ctx context.Context, model model.Post) (lastInsertID int64, err error) {
-	query := `INSERT INTO posts(user_id, post_title, post_content, post_hashtags, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, model.UserID, model.PostTitle, model.PostContent, model.PostHashtags, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
+// CreatePost stores the post with its tags and its first revision in one transaction
+func (r *postRepository) CreatePost(ctx context.Context, model model.Post, revision model.PostRevision) (lastInsertID int64, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO posts(user_id, post_title, post_content, status, publish_at, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query, model.UserID, model.PostTitle, model.PostContent, model.Status, model.PublishAt, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
 	if err != nil {
 		return
 	}
@@ -19,72 +31,346 @@ func (r *postRepository) CreatePost(ctx context.Context, model model.Post) (last
 		return
 	}
 
+	err = setPostTags(ctx, tx, lastInsertID, model.PostHashtags, model.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	revision.PostID = lastInsertID
+	err = insertPostRevision(ctx, tx, revision)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
 	return
 }
 
-func (r *postRepository) GetAllPost(ctx context.Context, limit, offset int) (resp model.GetAllPostResponse, err error) {
-	query := `SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.post_hashtags 
-	FROM posts p JOIN users u ON p.user_id = u.id ORDER BY p.updated_at DESC LIMIT ? OFFSET ?`
+// postSortKey is the expression a post listing is ordered by
+type postSortKey struct {
+	expr    string
+	desc    bool
+	numeric bool
+}
+
+// postSortKeys is the allow-list of sorts, the filter sort is never put in the query itself
+var postSortKeys = map[model.PostSort]postSortKey{
+	model.PostSortUpdated:       {expr: "p.updated_at", desc: true},
+	model.PostSortNewest:        {expr: "p.created_at", desc: true},
+	model.PostSortOldest:        {expr: "p.created_at"},
+	model.PostSortMostLiked:     {expr: likeCountExpr, desc: true, numeric: true},
+	model.PostSortMostCommented: {expr: commentCountExpr, desc: true, numeric: true},
+}
+
+// likeCountExpr and commentCountExpr count the likes and the visible comments
+// of the post. They are correlated to p so only the selected posts are counted.
+const (
+	likeCountExpr    = `(SELECT COUNT(*) FROM user_activities lc WHERE lc.post_id = p.id AND lc.reaction = 'like')`
+	commentCountExpr = `(SELECT COUNT(*) FROM comments cc WHERE cc.post_id = p.id AND cc.status = 'approved' AND cc.deleted_at IS NULL)`
+)
+
+// postDetailQuery selects the PostDetail columns scanned by queryPostDetails,
+// its two placeholders are the viewer id used to resolve is_liked and
+// is_bookmarked. Listings selecting extra columns put them between
+// postDetailColumns and postDetailJoins.
+const postDetailQuery = postDetailColumns + postDetailJoins
+
+const postDetailColumns = `SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.status, p.publish_at, p.comment_mode, p.created_at, p.updated_at,
+	` + likeCountExpr + `, ` + commentCountExpr + `, p.view_count,
+	EXISTS (SELECT 1 FROM user_activities va WHERE va.post_id = p.id AND va.user_id = ? AND va.reaction = 'like'), vb.post_id IS NOT NULL`
+
+const postDetailJoins = `
+	FROM posts p JOIN users u ON p.user_id = u.id
+	LEFT JOIN bookmarks vb ON vb.post_id = p.id AND vb.user_id = ?`
+
+// postDetailDest returns the scan destinations of the postDetailColumns
+func postDetailDest(post *model.PostDetail) []interface{} {
+	return []interface{}{&post.ID, &post.UserID, &post.Username, &post.PostTitle, &post.PostContent, &post.Status, &post.PublishAt, &post.CommentMode, &post.CreatedAt, &post.UpdatedAt, &post.LikeCount, &post.CommentCount, &post.ViewCount, &post.IsLiked, &post.IsBookmarked}
+}
+
+// notBlockedCondition leaves out the posts of the authors blocking the viewer,
+// its placeholder is the viewer id
+const notBlockedCondition = ` AND p.user_id NOT IN (SELECT blocker_id FROM blocks WHERE blocked_id = ?)`
+
+// notMutedCondition leaves out the posts of the authors muted by the viewer,
+// its placeholder is the viewer id
+const notMutedCondition = ` AND p.user_id NOT IN (SELECT muted_id FROM mutes WHERE muter_id = ?)`
+
+// postFilterCondition translates the filter into the WHERE clause of a post listing,
+// always restricted to the posts the viewer may see and leaving out muted authors
+func postFilterCondition(viewerID int64, filter model.PostFilter) (where string, args []interface{}) {
+	where = ` WHERE p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)` + notBlockedCondition + notMutedCondition
+	args = []interface{}{viewerID, viewerID, viewerID}
+
+	if filter.Author != "" {
+		where += ` AND u.username = ?`
+		args = append(args, filter.Author)
+	}
+	if filter.Hashtag != "" {
+		where += ` AND EXISTS (SELECT 1 FROM post_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.post_id = p.id AND t.name = ?)`
+		args = append(args, filter.Hashtag)
+	}
+	if filter.FollowedBy != 0 {
+		where += ` AND p.user_id IN (SELECT followee_id FROM follows WHERE follower_id = ?)`
+		args = append(args, filter.FollowedBy)
+	}
+	if filter.From != nil {
+		where += ` AND p.created_at >= ?`
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where += ` AND p.created_at < ?`
+		args = append(args, *filter.To)
+	}
+	return where, args
+}
+
+// GetAllPost lists published posts, plus the unpublished posts owned by the viewer,
+// matching the filter. With a cursor the page is selected by keyset on the sort
+// key and id instead of by offset, so writes between two fetches never shift the page.
+func (r *postRepository) GetAllPost(ctx context.Context, viewerID int64, filter model.PostFilter, page model.PageQuery) (resp model.GetAllPostResponse, err error) {
+	sortKey, ok := postSortKeys[filter.Sort]
+	if !ok {
+		sortKey = postSortKeys[model.PostSortUpdated]
+	}
+
+	where, args := postFilterCondition(viewerID, filter)
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts p JOIN users u ON p.user_id = u.id`+where, args...).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := postDetailQuery + where
+	args = append([]interface{}{viewerID, viewerID}, args...)
+
+	// walking backward reverses the order, the page is flipped back below
+	desc := sortKey.desc
+	if cursor := page.Cursor; cursor != nil {
+		desc = desc != cursor.Backward
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		query += ` AND (` + sortKey.expr + ` ` + op + ` ? OR (` + sortKey.expr + ` = ? AND p.id ` + op + ` ?))`
+
+		var value interface{} = cursor.Time
+		if sortKey.numeric {
+			value = cursor.Count
+		}
+		args = append(args, value, value, cursor.ID)
+	}
+
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+
+	// fetch one extra row to know whether there is a further page
+	query += ` ORDER BY ` + sortKey.expr + ` ` + order + `, p.id ` + order + ` LIMIT ?`
+	args = append(args, page.Limit+1)
+	if page.Cursor == nil {
+		query += ` OFFSET ?`
+		args = append(args, page.Offset)
+	}
+
+	data, err := queryPostDetails(ctx, r.db, query, args...)
+	if err != nil {
+		return
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	hasMore := len(data) > page.Limit
+	if hasMore {
+		data = data[:page.Limit]
+	}
+	if page.Cursor != nil && page.Cursor.Backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+		Total:   total,
+		HasMore: hasMore,
+	}
+	return
+}
+
+// queryPostDetails runs a post listing query selecting the PostDetail columns
+// and attaches the hashtags of every returned post
+func queryPostDetails(ctx context.Context, db *sql.DB, query string, args ...interface{}) (data []model.PostDetail, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
-	data := []model.PostDetail{}
+	data = []model.PostDetail{}
 	for rows.Next() {
-		var (
-			post     model.Post
-			username string
-		)
-		err = rows.Scan(&post.ID, &post.UserID, &username, &post.PostTitle, &post.PostContent, &post.PostHashtags)
+		var post model.PostDetail
+		err = rows.Scan(postDetailDest(&post)...)
 		if err != nil {
 			return
 		}
-		data = append(data, model.PostDetail{
-			ID:           post.ID,
-			UserID:       post.UserID,
-			Username:     username,
-			PostTitle:    post.PostTitle,
-			PostContent:  post.PostContent,
-			PostHashtags: strings.Split(post.PostHashtags, ","),
-		})
+		data = append(data, post)
 	}
-	resp.Data = data
-	resp.Pagination = model.Pagination{
-		Limit:  limit,
-		Offset: offset,
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	postIDs := make([]int64, 0, len(data))
+	for _, post := range data {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	tags, err := getTagsByPostIDs(ctx, db, postIDs)
+	if err != nil {
+		return
+	}
+
+	for i := range data {
+		data[i].PostHashtags = tags[data[i].ID]
 	}
 	return
 }
 
-func (r *postRepository) GetPostByID(ctx context.Context, id int64) (resp model.PostDetail, err error) {
-	query := `SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.post_hashtags, uv.is_liked 
-	FROM posts p JOIN users u ON p.user_id = u.id 
-	JOIN user_activities uv ON uv.post_id = p.id 
-	WHERE p.id = ?`
-
-	var (
-		post     model.Post
-		username string
-		isLiked  bool
-	)
+// GetPostByID returns the post with the viewer's like state, or an empty
+// PostDetail when the post does not exist or the viewer may not see it
+func (r *postRepository) GetPostByID(ctx context.Context, id, viewerID int64) (resp model.PostDetail, err error) {
+	query := postDetailQuery + ` WHERE p.id = ? AND p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)` + notBlockedCondition
+
+	data, err := queryPostDetails(ctx, r.db, query, viewerID, viewerID, id, viewerID, viewerID)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	return data[0], nil
+}
+
+func (r *postRepository) GetPost(ctx context.Context, id int64) (post model.Post, err error) {
+	query := `SELECT id, user_id, post_title, post_content, status, publish_at, comment_mode, created_at, updated_at, created_by, updated_by, deleted_at FROM posts WHERE id = ?`
+
 	row := r.db.QueryRowContext(ctx, query, id)
+	err = row.Scan(&post.ID, &post.UserID, &post.PostTitle, &post.PostContent, &post.Status, &post.PublishAt, &post.CommentMode, &post.CreatedAt, &post.UpdatedAt, &post.CreatedBy, &post.UpdatedBy, &post.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return post, nil
+		}
+		return
+	}
 
-	err = row.Scan(&post.ID, &post.UserID, &username, &post.PostTitle, &post.PostContent, &post.PostHashtags, &isLiked)
+	tags, err := getTagsByPostIDs(ctx, r.db, []int64{post.ID})
 	if err != nil {
 		return
 	}
 
-	resp = model.PostDetail{
-		ID:           post.ID,
-		UserID:       post.UserID,
-		Username:     username,
-		PostTitle:    post.PostTitle,
-		PostContent:  post.PostContent,
-		PostHashtags: strings.Split(post.PostHashtags, ","),
-		IsLiked:      isLiked,
+	post.PostHashtags = tags[post.ID]
+	return
+}
+
+// UpdatePost saves the post with its tags and appends the revisions, in order,
+// in one transaction
+func (r *postRepository) UpdatePost(ctx context.Context, model model.Post, revisions []model.PostRevision) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE posts SET post_title = ?, post_content = ?, status = ?, publish_at = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, model.PostTitle, model.PostContent, model.Status, model.PublishAt, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+
+	err = setPostTags(ctx, tx, model.ID, model.PostHashtags, model.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range revisions {
+		err = insertPostRevision(ctx, tx, revision)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPendingPosts lists the posts held for moderation, oldest first
+func (r *postRepository) GetPendingPosts(ctx context.Context, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error) {
+	where := ` WHERE p.deleted_at IS NULL AND p.status = 'pending'`
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts p`+where).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := postDetailQuery + where + ` ORDER BY p.updated_at, p.id LIMIT ? OFFSET ?`
+	data, err := queryPostDetails(ctx, r.db, query, viewerID, viewerID, limit, offset)
+	if err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
 	}
 	return
 }
+
+func (r *postRepository) UpdatePostStatus(ctx context.Context, model model.Post) (err error) {
+	query := `UPDATE posts SET status = ?, publish_at = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.Status, model.PublishAt, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *postRepository) UpdatePostCommentMode(ctx context.Context, model model.Post) (err error) {
+	query := `UPDATE posts SET comment_mode = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.CommentMode, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PublishDuePosts publishes every scheduled post whose publish_at has passed
+func (r *postRepository) PublishDuePosts(ctx context.Context, now time.Time) (published int64, err error) {
+	query := `UPDATE posts SET status = 'published', updated_at = ?, updated_by = 'scheduler'
+	WHERE status = 'scheduled' AND publish_at <= ? AND deleted_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, now, now)
+	if err != nil {
+		return 0, err
+	}
+
+	published, err = res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return published, nil
+}
+
+// DeletePost moves the post to the trash, the row is removed later by PurgeTrash
+func (r *postRepository) DeletePost(ctx context.Context, model model.Post) (err error) {
+	query := `UPDATE posts SET deleted_at = ?, updated_at = ?, updated_by = ? WHERE id = ? AND deleted_at IS NULL`
+	_, err = r.db.ExecContext(ctx, query, model.DeletedAt, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
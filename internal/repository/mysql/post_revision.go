@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// insertPostRevision stores the revision with the next revision number of the
+// post, within the transaction saving the post
+func insertPostRevision(ctx context.Context, tx *sql.Tx, model model.PostRevision) (err error) {
+	hashtags, err := json.Marshal(model.PostHashtags)
+	if err != nil {
+		return
+	}
+
+	query := `INSERT INTO post_revisions (post_id, revision, post_title, post_content, post_hashtags, editor_id, created_at, created_by)
+	SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ?, ?, ?, ? FROM post_revisions WHERE post_id = ?`
+	_, err = tx.ExecContext(ctx, query, model.PostID, model.PostTitle, model.PostContent, string(hashtags), model.EditorID, model.CreatedAt, model.CreatedBy, model.PostID)
+	return
+}
+
+func (r *postRepository) CountPostRevisions(ctx context.Context, postID int64) (count int, err error) {
+	query := `SELECT COUNT(id) FROM post_revisions WHERE post_id = ?`
+
+	row := r.db.QueryRowContext(ctx, query, postID)
+	err = row.Scan(&count)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func (r *postRepository) GetPostRevisions(ctx context.Context, postID int64) (revisions []model.PostRevisionSummary, err error) {
+	query := `SELECT pr.revision, pr.post_title, pr.editor_id, COALESCE(u.username, ''), pr.created_at
+	FROM post_revisions pr LEFT JOIN users u ON pr.editor_id = u.id
+	WHERE pr.post_id = ? ORDER BY pr.revision DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	revisions = []model.PostRevisionSummary{}
+	for rows.Next() {
+		var revision model.PostRevisionSummary
+		err = rows.Scan(&revision.Revision, &revision.PostTitle, &revision.EditorID, &revision.EditorUsername, &revision.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return
+}
+
+func (r *postRepository) GetPostRevision(ctx context.Context, postID int64, revision int) (resp model.PostRevision, err error) {
+	query := `SELECT id, post_id, revision, post_title, post_content, post_hashtags, editor_id, created_at, created_by
+	FROM post_revisions WHERE post_id = ? AND revision = ?`
+
+	return r.scanPostRevision(r.db.QueryRowContext(ctx, query, postID, revision))
+}
+
+// GetPreviousPostRevision returns the latest revision older than the given one
+func (r *postRepository) GetPreviousPostRevision(ctx context.Context, postID int64, revision int) (resp model.PostRevision, err error) {
+	query := `SELECT id, post_id, revision, post_title, post_content, post_hashtags, editor_id, created_at, created_by
+	FROM post_revisions WHERE post_id = ? AND revision < ? ORDER BY revision DESC LIMIT 1`
+
+	return r.scanPostRevision(r.db.QueryRowContext(ctx, query, postID, revision))
+}
+
+func (r *postRepository) scanPostRevision(row *sql.Row) (resp model.PostRevision, err error) {
+	var hashtags string
+	err = row.Scan(&resp.ID, &resp.PostID, &resp.Revision, &resp.PostTitle, &resp.PostContent, &hashtags, &resp.EditorID, &resp.CreatedAt, &resp.CreatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return resp, nil
+		}
+		return
+	}
+
+	err = json.Unmarshal([]byte(hashtags), &resp.PostHashtags)
+	if err != nil {
+		return
+	}
+	return
+}
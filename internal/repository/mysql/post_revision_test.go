@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestInsertPostRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	editorID := int64(2)
+	revision := model.PostRevision{
+		PostID:       1,
+		PostTitle:    "Title",
+		PostContent:  "Content",
+		PostHashtags: []string{"go", "sql"},
+		EditorID:     &editorID,
+		CreatedAt:    time.Now(),
+		CreatedBy:    "2",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO post_revisions \(post_id, revision, post_title, post_content, post_hashtags, editor_id, created_at, created_by\) SELECT \?, COALESCE\(MAX\(revision\), 0\) \+ 1`).
+		WithArgs(revision.PostID, revision.PostTitle, revision.PostContent, `["go","sql"]`, revision.EditorID, revision.CreatedAt, revision.CreatedBy, revision.PostID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	err = insertPostRevision(ctx, tx, revision)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountPostRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT COUNT\(id\) FROM post_revisions WHERE post_id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountPostRevisions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPostRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT pr.revision, pr.post_title, pr.editor_id, COALESCE\(u.username, ''\), pr.created_at FROM post_revisions pr LEFT JOIN users u ON pr.editor_id = u.id WHERE pr.post_id = \? ORDER BY pr.revision DESC`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"revision", "post_title", "editor_id", "username", "created_at"}).
+			AddRow(2, "Title 2", 2, "user2", now).
+			AddRow(1, "Title 1", nil, "", now))
+
+	revisions, err := repo.GetPostRevisions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, int64(2), *revisions[0].EditorID)
+	assert.Equal(t, "user2", revisions[0].EditorUsername)
+	assert.Nil(t, revisions[1].EditorID)
+	assert.Empty(t, revisions[1].EditorUsername)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPostRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{"id", "post_id", "revision", "post_title", "post_content", "post_hashtags", "editor_id", "created_at", "created_by"}
+
+	t.Run("Success GetPostRevision", func(t *testing.T) {
+		mock.ExpectQuery(`FROM post_revisions WHERE post_id = \? AND revision = \?`).
+			WithArgs(int64(1), 2).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 1, 2, "Title", "Content", `["go"]`, 2, now, "2"))
+
+		revision, err := repo.GetPostRevision(ctx, 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), revision.ID)
+		assert.Equal(t, []string{"go"}, revision.PostHashtags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetPreviousPostRevision - None", func(t *testing.T) {
+		mock.ExpectQuery(`FROM post_revisions WHERE post_id = \? AND revision < \? ORDER BY revision DESC LIMIT 1`).
+			WithArgs(int64(1), 1).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		revision, err := repo.GetPreviousPostRevision(ctx, 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), revision.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
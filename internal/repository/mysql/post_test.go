@@ -2,7 +2,6 @@ package mysql
 
 import (
 	"context"
-	"strings"
 	"testing"
 	"time"
 
@@ -19,28 +18,264 @@ func TestCreatePost(t *testing.T) {
 	repo := &postRepository{db: db}
 
 	ctx := context.Background()
+	now := time.Now()
 	post := model.Post{
 		UserID:       1,
 		PostTitle:    "Test Title",
 		PostContent:  "Test Content",
-		PostHashtags: "test,go,sqlmock",
+		PostHashtags: []string{"test", "go"},
+		Status:       model.PostStatusPublished,
+		PublishAt:    &now,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		CreatedBy:    "test_user",
 		UpdatedBy:    "test_user",
 	}
+	editorID := int64(1)
+	revision := model.PostRevision{PostTitle: post.PostTitle, PostContent: post.PostContent, PostHashtags: post.PostHashtags, EditorID: &editorID, CreatedAt: post.CreatedAt, CreatedBy: "1"}
 
+	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO posts`).
-		WithArgs(post.UserID, post.PostTitle, post.PostContent, post.PostHashtags, post.CreatedAt, post.UpdatedAt, post.CreatedBy, post.UpdatedBy).
+		WithArgs(post.UserID, post.PostTitle, post.PostContent, post.Status, post.PublishAt, post.CreatedAt, post.UpdatedAt, post.CreatedBy, post.UpdatedBy).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`DELETE FROM post_tags WHERE post_id = \?`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO tags \(name, created_at\) VALUES \(\?, \?\) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID\(id\)`).
+		WithArgs("test", post.CreatedAt).WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(`INSERT INTO post_tags \(post_id, tag_id\) VALUES \(\?, \?\)`).WithArgs(int64(1), int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO tags`).WithArgs("go", post.CreatedAt).WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectExec(`INSERT INTO post_tags`).WithArgs(int64(1), int64(8)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO post_revisions`).
+		WithArgs(int64(1), post.PostTitle, post.PostContent, `["test","go"]`, int64(1), post.CreatedAt, "1", int64(1)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	lastInsertID, err := repo.CreatePost(ctx, post)
+	lastInsertID, err := repo.CreatePost(ctx, post, revision)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), lastInsertID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetAllPost(t *testing.T) {
+	ctx := context.Background()
+	viewerID := int64(3)
+	now := time.Now()
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked"}
+	defaultFilter := model.PostFilter{Sort: model.PostSortUpdated}
+
+	t.Run("Success GetAllPost - Offset", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		page := model.PageQuery{Limit: 10, Offset: 0}
+
+		expectedPosts := []model.PostDetail{
+			{ID: 1, UserID: 2, Username: "user1", PostTitle: "Title 1", PostContent: "Content 1", PostHashtags: []string{"tag1", "tag2"}, Status: model.PostStatusPublished, CommentMode: model.CommentModeOpen, CreatedAt: now, UpdatedAt: now, LikeCount: 4, CommentCount: 1, IsLiked: true},
+			{ID: 2, UserID: 3, Username: "user2", PostTitle: "Title 2", PostContent: "Content 2", PostHashtags: []string{"tag3", "tag4"}, Status: model.PostStatusDraft, CommentMode: model.CommentModeOpen, CreatedAt: now, UpdatedAt: now},
+		}
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(expectedPosts[0].ID, expectedPosts[0].UserID, expectedPosts[0].Username, expectedPosts[0].PostTitle, expectedPosts[0].PostContent, expectedPosts[0].Status, nil, "open", now, now, 4, 1, 0, true, false).
+			AddRow(expectedPosts[1].ID, expectedPosts[1].UserID, expectedPosts[1].Username, expectedPosts[1].PostTitle, expectedPosts[1].PostContent, expectedPosts[1].Status, nil, "open", now, now, 0, 0, 0, false, false)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p JOIN users u ON p.user_id = u.id WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\)$`).
+			WithArgs(viewerID, viewerID, viewerID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		mock.ExpectQuery(`SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.status, p.publish_at, p.comment_mode, p.created_at, p.updated_at, \(SELECT COUNT\(\*\) FROM user_activities lc WHERE lc.post_id = p.id AND lc.reaction = 'like'\), \(SELECT COUNT\(\*\) FROM comments cc WHERE .*\), p.view_count, EXISTS \(SELECT 1 FROM user_activities va WHERE va.post_id = p.id AND va.user_id = \? AND va.reaction = 'like'\), vb.post_id IS NOT NULL FROM posts p JOIN users u ON p.user_id = u.id LEFT JOIN bookmarks vb ON vb.post_id = p.id AND vb.user_id = \? WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) ORDER BY p.updated_at DESC, p.id DESC LIMIT \? OFFSET \?`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID, viewerID, page.Limit+1, page.Offset).
+			WillReturnRows(rows)
+
+		mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.post_id IN \(\?, \?\)`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).
+				AddRow(1, "tag1").AddRow(1, "tag2").AddRow(2, "tag3").AddRow(2, "tag4"))
+
+		resp, err := repo.GetAllPost(ctx, viewerID, defaultFilter, page)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPosts, resp.Data)
+		assert.Equal(t, model.Pagination{Limit: page.Limit, Offset: page.Offset, Total: 12}, resp.Pagination)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetAllPost - Filters", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		page := model.PageQuery{Limit: 5}
+		from := now.Add(-24 * time.Hour)
+		filter := model.PostFilter{Author: "user1", Hashtag: "golang", From: &from, To: &now, Sort: model.PostSortOldest}
+		where := `WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) AND u.username = \? AND EXISTS \(SELECT 1 FROM post_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.post_id = p.id AND t.name = \?\) AND p.created_at >= \? AND p.created_at < \?`
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p JOIN users u ON p.user_id = u.id `+where+`$`).
+			WithArgs(viewerID, viewerID, viewerID, "user1", "golang", from, now).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectQuery(where+` ORDER BY p.created_at ASC, p.id ASC LIMIT \? OFFSET \?`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID, viewerID, "user1", "golang", from, now, page.Limit+1, page.Offset).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		resp, err := repo.GetAllPost(ctx, viewerID, filter, page)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.Equal(t, 0, resp.Pagination.Total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetAllPost - Followed Authors", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		page := model.PageQuery{Limit: 5}
+		filter := model.PostFilter{FollowedBy: viewerID, Sort: model.PostSortNewest}
+		where := `WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) AND p.user_id IN \(SELECT followee_id FROM follows WHERE follower_id = \?\)`
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p JOIN users u ON p.user_id = u.id `+where+`$`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		mock.ExpectQuery(where+` ORDER BY p.created_at DESC, p.id DESC LIMIT \? OFFSET \?`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID, viewerID, viewerID, page.Limit+1, page.Offset).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		resp, err := repo.GetAllPost(ctx, viewerID, filter, page)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetAllPost - Forward Cursor With More", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		cursor := model.Cursor{Time: now, ID: 9}
+		page := model.PageQuery{Limit: 2, Cursor: &cursor}
+
+		mock.ExpectQuery(`SELECT COUNT`).WithArgs(viewerID, viewerID, viewerID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+		mock.ExpectQuery(`AND \(p.updated_at < \? OR \(p.updated_at = \? AND p.id < \?\)\) ORDER BY p.updated_at DESC, p.id DESC LIMIT \?$`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID, viewerID, now, now, int64(9), 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(8, 2, "user1", "Title 8", "Content 8", "published", nil, "open", now, now, 0, 0, 0, false, false).
+				AddRow(7, 2, "user1", "Title 7", "Content 7", "published", nil, "open", now, now, 0, 0, 0, false, false).
+				AddRow(6, 2, "user1", "Title 6", "Content 6", "published", nil, "open", now, now, 0, 0, 0, false, false))
+
+		mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+			WithArgs(int64(8), int64(7), int64(6)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}))
+
+		resp, err := repo.GetAllPost(ctx, viewerID, defaultFilter, page)
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, int64(8), resp.Data[0].ID)
+		assert.Equal(t, int64(7), resp.Data[1].ID)
+		assert.True(t, resp.Pagination.HasMore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetAllPost - Backward Cursor By Likes", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		cursor := model.Cursor{Sort: string(model.PostSortMostLiked), Count: 3, ID: 6, Backward: true}
+		page := model.PageQuery{Limit: 2, Cursor: &cursor}
+
+		mock.ExpectQuery(`SELECT COUNT`).WithArgs(viewerID, viewerID, viewerID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+		mock.ExpectQuery(`AND \(\(SELECT COUNT\(\*\) FROM user_activities lc WHERE lc.post_id = p.id AND lc.reaction = 'like'\) > \? OR \(\(SELECT COUNT\(\*\) FROM user_activities lc WHERE lc.post_id = p.id AND lc.reaction = 'like'\) = \? AND p.id > \?\)\) ORDER BY \(SELECT COUNT\(\*\) FROM user_activities lc WHERE lc.post_id = p.id AND lc.reaction = 'like'\) ASC, p.id ASC LIMIT \?$`).
+			WithArgs(viewerID, viewerID, viewerID, viewerID, viewerID, int64(3), int64(3), int64(6), 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(7, 2, "user1", "Title 7", "Content 7", "published", nil, "open", now, now, 3, 0, 0, false, false).
+				AddRow(8, 2, "user1", "Title 8", "Content 8", "published", nil, "open", now, now, 5, 0, 0, false, false))
+
+		mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+			WithArgs(int64(7), int64(8)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}))
+
+		resp, err := repo.GetAllPost(ctx, viewerID, model.PostFilter{Sort: model.PostSortMostLiked}, page)
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, int64(8), resp.Data[0].ID)
+		assert.Equal(t, int64(7), resp.Data[1].ID)
+		assert.False(t, resp.Pagination.HasMore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPostByID(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	viewerID := int64(3)
+	now := time.Now()
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked"}
+	query := `SELECT p.id, .*, EXISTS \(SELECT 1 FROM user_activities va WHERE va.post_id = p.id AND va.user_id = \? AND va.reaction = 'like'\), vb.post_id IS NOT NULL FROM posts p JOIN users u ON p.user_id = u.id LEFT JOIN bookmarks vb ON vb.post_id = p.id AND vb.user_id = \? WHERE p.id = \? AND p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\)$`
+
+	t.Run("Success GetPostByID", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+
+		expectedPost := model.PostDetail{
+			ID:           1,
+			UserID:       2,
+			Username:     "user1",
+			PostTitle:    "Title 1",
+			PostContent:  "Content 1",
+			PostHashtags: []string{"tag1", "tag2"},
+			Status:       model.PostStatusPublished,
+			CommentMode:  model.CommentModeOpen,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+			LikeCount:    3,
+			CommentCount: 2,
+			ViewCount:    42,
+			IsLiked:      true,
+			IsBookmarked: true,
+		}
+
+		mock.ExpectQuery(query).
+			WithArgs(viewerID, viewerID, postID, viewerID, viewerID).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(expectedPost.ID, expectedPost.UserID, expectedPost.Username, expectedPost.PostTitle, expectedPost.PostContent, expectedPost.Status, nil, "open", now, now, 3, 2, 42, true, true))
+
+		mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+			WithArgs(postID).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).AddRow(1, "tag1").AddRow(1, "tag2"))
+
+		resp, err := repo.GetPostByID(ctx, postID, viewerID)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPost, resp)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetPostByID - No Likes And Not Found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+
+		mock.ExpectQuery(query).
+			WithArgs(viewerID, viewerID, postID, viewerID, viewerID).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		resp, err := repo.GetPostByID(ctx, postID, viewerID)
+		assert.NoError(t, err)
+		assert.Empty(t, resp)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPost(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
@@ -48,30 +283,31 @@ func TestGetAllPost(t *testing.T) {
 	repo := &postRepository{db: db}
 
 	ctx := context.Background()
-	limit, offset := 10, 0
-
-	expectedPosts := []model.PostDetail{
-		{ID: 1, UserID: 2, Username: "user1", PostTitle: "Title 1", PostContent: "Content 1", PostHashtags: []string{"tag1", "tag2"}},
-		{ID: 2, UserID: 3, Username: "user2", PostTitle: "Title 2", PostContent: "Content 2", PostHashtags: []string{"tag3", "tag4"}},
-	}
+	postID := int64(1)
+	now := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "username", "post_title", "post_content", "post_hashtags"}).
-		AddRow(expectedPosts[0].ID, expectedPosts[0].UserID, expectedPosts[0].Username, expectedPosts[0].PostTitle, expectedPosts[0].PostContent, strings.Join(expectedPosts[0].PostHashtags, ",")).
-		AddRow(expectedPosts[1].ID, expectedPosts[1].UserID, expectedPosts[1].Username, expectedPosts[1].PostTitle, expectedPosts[1].PostContent, strings.Join(expectedPosts[1].PostHashtags, ","))
+	rows := sqlmock.NewRows([]string{"id", "user_id", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "created_by", "updated_by", "deleted_at"}).
+		AddRow(postID, 2, "Title 1", "Content 1", "draft", nil, "open", now, now, "2", "2", nil)
 
-	mock.ExpectQuery(`SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.post_hashtags FROM posts p JOIN users u ON p.user_id = u.id`).
-		WithArgs(limit, offset).
+	mock.ExpectQuery(`SELECT id, user_id, post_title, post_content, status, publish_at, comment_mode, created_at, updated_at, created_by, updated_by, deleted_at FROM posts WHERE id = \?`).
+		WithArgs(postID).
 		WillReturnRows(rows)
 
-	resp, err := repo.GetAllPost(ctx, limit, offset)
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(postID).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).AddRow(1, "tag1"))
+
+	post, err := repo.GetPost(ctx, postID)
 	assert.NoError(t, err)
-	assert.Equal(t, expectedPosts, resp.Data)
-	assert.Equal(t, limit, resp.Pagination.Limit)
-	assert.Equal(t, offset, resp.Pagination.Offset)
+	assert.Equal(t, postID, post.ID)
+	assert.Equal(t, int64(2), post.UserID)
+	assert.Equal(t, model.PostStatusDraft, post.Status)
+	assert.Equal(t, []string{"tag1"}, post.PostHashtags)
+	assert.Nil(t, post.DeletedAt)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetPostByID(t *testing.T) {
+func TestUpdatePost(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
@@ -79,27 +315,146 @@ func TestGetPostByID(t *testing.T) {
 	repo := &postRepository{db: db}
 
 	ctx := context.Background()
-	postID := int64(1)
-
-	expectedPost := model.PostDetail{
+	post := model.Post{
 		ID:           1,
-		UserID:       2,
-		Username:     "user1",
-		PostTitle:    "Title 1",
-		PostContent:  "Content 1",
-		PostHashtags: []string{"tag1", "tag2"},
-		IsLiked:      true,
+		PostTitle:    "Updated Title",
+		PostContent:  "Updated Content",
+		PostHashtags: []string{"go"},
+		Status:       model.PostStatusPending,
+		UpdatedAt:    time.Now(),
+		UpdatedBy:    "2",
+	}
+	editorID := int64(2)
+	revisions := []model.PostRevision{
+		{PostID: post.ID, PostTitle: "Original Title", PostContent: "Original Content", PostHashtags: []string{}, EditorID: &editorID, CreatedAt: post.UpdatedAt, CreatedBy: "2"},
+		{PostID: post.ID, PostTitle: post.PostTitle, PostContent: post.PostContent, PostHashtags: post.PostHashtags, EditorID: &editorID, CreatedAt: post.UpdatedAt, CreatedBy: "2"},
 	}
 
-	row := sqlmock.NewRows([]string{"id", "user_id", "username", "post_title", "post_content", "post_hashtags", "is_liked"}).
-		AddRow(expectedPost.ID, expectedPost.UserID, expectedPost.Username, expectedPost.PostTitle, expectedPost.PostContent, strings.Join(expectedPost.PostHashtags, ","), expectedPost.IsLiked)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE posts SET post_title = \?, post_content = \?, status = \?, publish_at = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(post.PostTitle, post.PostContent, post.Status, post.PublishAt, post.UpdatedAt, post.UpdatedBy, post.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM post_tags WHERE post_id = \?`).WithArgs(post.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO tags`).WithArgs("go", post.UpdatedAt).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(`INSERT INTO post_tags`).WithArgs(post.ID, int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO post_revisions`).
+		WithArgs(post.ID, "Original Title", "Original Content", `[]`, int64(2), post.UpdatedAt, "2", post.ID).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec(`INSERT INTO post_revisions`).
+		WithArgs(post.ID, post.PostTitle, post.PostContent, `["go"]`, int64(2), post.UpdatedAt, "2", post.ID).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
 
-	mock.ExpectQuery(`SELECT p.id, p.user_id, u.username, p.post_title, p.post_content, p.post_hashtags, uv.is_liked FROM posts p JOIN users u ON p.user_id = u.id`).
-		WithArgs(postID).
-		WillReturnRows(row)
+	err = repo.UpdatePost(ctx, post, revisions)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPendingPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	viewerID := int64(9)
+	where := `WHERE p.deleted_at IS NULL AND p.status = 'pending'`
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p ` + where + `$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT p.id, .* FROM posts p .* `+where+` ORDER BY p.updated_at, p.id LIMIT \? OFFSET \?`).
+		WithArgs(viewerID, viewerID, 2, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 1, "author", "Title", "Content", "pending", nil, "open", now, now, 0, 0, 0, false, false))
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}))
+
+	resp, err := repo.GetPendingPosts(ctx, viewerID, 2, 0)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, model.PostStatusPending, resp.Data[0].Status)
+	assert.Equal(t, model.Pagination{Limit: 2, Total: 3, HasMore: true}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePostStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	post := model.Post{ID: 1, Status: model.PostStatusScheduled, PublishAt: &publishAt, UpdatedAt: time.Now(), UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE posts SET status = \?, publish_at = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(post.Status, post.PublishAt, post.UpdatedAt, post.UpdatedBy, post.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdatePostStatus(ctx, post)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePostCommentMode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	post := model.Post{ID: 1, CommentMode: model.CommentModeApproval, UpdatedAt: time.Now(), UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE posts SET comment_mode = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(post.CommentMode, post.UpdatedAt, post.UpdatedBy, post.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdatePostCommentMode(ctx, post)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPublishDuePosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectExec(`UPDATE posts SET status = 'published', updated_at = \?, updated_by = 'scheduler' WHERE status = 'scheduled' AND publish_at <= \? AND deleted_at IS NULL`).
+		WithArgs(now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	published, err := repo.PublishDuePosts(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), published)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeletePost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+
+	ctx := context.Background()
+	now := time.Now()
+	post := model.Post{ID: 1, DeletedAt: &now, UpdatedAt: now, UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE posts SET deleted_at = \?, updated_at = \?, updated_by = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(post.DeletedAt, post.UpdatedAt, post.UpdatedBy, post.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	resp, err := repo.GetPostByID(ctx, postID)
+	err = repo.DeletePost(ctx, post)
 	assert.NoError(t, err)
-	assert.Equal(t, expectedPost, resp)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// Search ranks visible posts by the FULLTEXT relevance of their title, content
+// and hashtags and, when requested, comments by the relevance of their content.
+// Posts of authors blocking or muted by the viewer are left out, with their comments.
+func (r *searchRepository) Search(ctx context.Context, query model.SearchQuery) (hits []model.SearchHit, err error) {
+	sqlQuery := `SELECT 'post' AS type, p.id AS post_id, 0 AS comment_id, p.post_title, p.post_content AS content,
+		MATCH(p.post_title, p.post_content) AGAINST (? IN NATURAL LANGUAGE MODE) + COALESCE(tm.score, 0) AS score
+	FROM posts p
+	LEFT JOIN (
+		SELECT pt.post_id, SUM(MATCH(t.name) AGAINST (? IN NATURAL LANGUAGE MODE)) AS score
+		FROM post_tags pt JOIN tags t ON pt.tag_id = t.id
+		WHERE MATCH(t.name) AGAINST (? IN NATURAL LANGUAGE MODE)
+		GROUP BY pt.post_id
+	) tm ON tm.post_id = p.id
+	WHERE p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)
+	AND (MATCH(p.post_title, p.post_content) AGAINST (? IN NATURAL LANGUAGE MODE) OR tm.post_id IS NOT NULL)` + notBlockedCondition + notMutedCondition
+	args := []interface{}{query.Query, query.Query, query.Query, query.ViewerID, query.Query, query.ViewerID, query.ViewerID}
+
+	if query.IncludeComments {
+		sqlQuery += `
+	UNION ALL
+	SELECT 'comment' AS type, c.post_id, c.id AS comment_id, p.post_title, c.comment_content AS content,
+		MATCH(c.comment_content) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+	FROM comments c JOIN posts p ON c.post_id = p.id
+	WHERE c.status = 'approved' AND c.deleted_at IS NULL AND p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)
+	AND MATCH(c.comment_content) AGAINST (? IN NATURAL LANGUAGE MODE)` + notBlockedCondition + notMutedCondition
+		args = append(args, query.Query, query.ViewerID, query.Query, query.ViewerID, query.ViewerID)
+	}
+
+	sqlQuery += `
+	ORDER BY score DESC, post_id DESC, comment_id LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	hits = []model.SearchHit{}
+	for rows.Next() {
+		var hit model.SearchHit
+		err = rows.Scan(&hit.Type, &hit.PostID, &hit.CommentID, &hit.PostTitle, &hit.Content, &hit.Score)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"type", "post_id", "comment_id", "post_title", "content", "score"}
+
+	t.Run("Success Search - Posts Only", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &searchRepository{db: db}
+		query := model.SearchQuery{Query: "golang", ViewerID: 2, Limit: 10, Offset: 0}
+
+		mock.ExpectQuery(`SELECT 'post' AS type, p.id AS post_id, 0 AS comment_id, p.post_title, p.post_content AS content, MATCH\(p.post_title, p.post_content\) AGAINST \(\? IN NATURAL LANGUAGE MODE\) \+ COALESCE\(tm.score, 0\) AS score FROM posts p LEFT JOIN .* WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND .* AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) ORDER BY score DESC, post_id DESC, comment_id LIMIT \? OFFSET \?$`).
+			WithArgs("golang", "golang", "golang", int64(2), "golang", int64(2), int64(2), 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("post", 1, 0, "Golang tips", "Learn golang", 1.5))
+
+		hits, err := repo.Search(ctx, query)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.SearchHit{{Type: model.SearchResultTypePost, PostID: 1, PostTitle: "Golang tips", Content: "Learn golang", Score: 1.5}}, hits)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success Search - With Comments", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &searchRepository{db: db}
+		query := model.SearchQuery{Query: "golang", IncludeComments: true, ViewerID: 2, Limit: 10, Offset: 10}
+
+		mock.ExpectQuery(`UNION ALL SELECT 'comment' AS type, c.post_id, c.id AS comment_id, p.post_title, c.comment_content AS content, MATCH\(c.comment_content\) AGAINST \(\? IN NATURAL LANGUAGE MODE\) AS score FROM comments c JOIN posts p ON c.post_id = p.id WHERE c.status = 'approved' AND c.deleted_at IS NULL`).
+			WithArgs("golang", "golang", "golang", int64(2), "golang", int64(2), int64(2), "golang", int64(2), "golang", int64(2), int64(2), 10, 10).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("post", 1, 0, "Golang tips", "Learn golang", 1.5).
+				AddRow("comment", 1, 4, "Golang tips", "golang is great", 0.7))
+
+		hits, err := repo.Search(ctx, query)
+		assert.NoError(t, err)
+		assert.Len(t, hits, 2)
+		assert.Equal(t, int64(4), hits[1].CommentID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,109 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// setPostTags replaces the tags of a post, creating the tags that do not exist yet
+func setPostTags(ctx context.Context, tx *sql.Tx, postID int64, tags []string, now time.Time) (err error) {
+	_, err = tx.ExecContext(ctx, `DELETE FROM post_tags WHERE post_id = ?`, postID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		var (
+			res   sql.Result
+			tagID int64
+		)
+		// LAST_INSERT_ID(id) makes LastInsertId return the existing id on duplicates
+		res, err = tx.ExecContext(ctx, `INSERT INTO tags (name, created_at) VALUES (?, ?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)`, tag, now)
+		if err != nil {
+			return err
+		}
+
+		tagID, err = res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO post_tags (post_id, tag_id) VALUES (?, ?)`, postID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTagsByPostIDs returns the tag names of every given post, keyed by post id
+func getTagsByPostIDs(ctx context.Context, db *sql.DB, postIDs []int64) (tags map[int64][]string, err error) {
+	tags = make(map[int64][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return tags, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(postIDs)), ", ")
+	args := make([]interface{}, 0, len(postIDs))
+	for _, postID := range postIDs {
+		args = append(args, postID)
+		tags[postID] = []string{}
+	}
+
+	query := `SELECT pt.post_id, t.name FROM post_tags pt JOIN tags t ON pt.tag_id = t.id
+	WHERE pt.post_id IN (` + placeholders + `) ORDER BY t.name`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID int64
+			name   string
+		)
+		err = rows.Scan(&postID, &name)
+		if err != nil {
+			return nil, err
+		}
+		tags[postID] = append(tags[postID], name)
+	}
+	return tags, rows.Err()
+}
+
+// GetTags lists the tags used by published posts together with their post count
+func (r *postRepository) GetTags(ctx context.Context, limit, offset int) (resp model.GetTagsResponse, err error) {
+	query := `SELECT t.name, COUNT(p.id) AS post_count
+	FROM tags t JOIN post_tags pt ON pt.tag_id = t.id
+	JOIN posts p ON p.id = pt.post_id AND p.deleted_at IS NULL AND p.status = 'published'
+	GROUP BY t.id, t.name ORDER BY post_count DESC, t.name LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.Tag{}
+	for rows.Next() {
+		var tag model.Tag
+		err = rows.Scan(&tag.Name, &tag.PostCount)
+		if err != nil {
+			return
+		}
+		data = append(data, tag)
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:  limit,
+		Offset: offset,
+	}
+	return
+}
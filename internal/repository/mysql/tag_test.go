@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	limit, offset := 10, 0
+
+	mock.ExpectQuery(`SELECT t.name, COUNT\(p.id\) AS post_count FROM tags t JOIN post_tags pt ON pt.tag_id = t.id JOIN posts p ON p.id = pt.post_id AND p.deleted_at IS NULL AND p.status = 'published' GROUP BY t.id, t.name`).
+		WithArgs(limit, offset).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "post_count"}).
+			AddRow("golang", 3).
+			AddRow("sql", 1))
+
+	resp, err := repo.GetTags(ctx, limit, offset)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Tag{{Name: "golang", PostCount: 3}, {Name: "sql", PostCount: 1}}, resp.Data)
+	assert.Equal(t, limit, resp.Pagination.Limit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func (r *postRepository) RestorePost(ctx context.Context, model model.Post) (err error) {
+	query := `UPDATE posts SET deleted_at = NULL, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *postRepository) RestoreComment(ctx context.Context, model model.Comment) (err error) {
+	query := `UPDATE comments SET deleted_at = NULL, deleted_by = NULL, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.UpdatedAt, model.UpdatedBy, model.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *postRepository) GetTrashedPosts(ctx context.Context, userID int64) (posts []model.TrashedPost, err error) {
+	query := `SELECT id, post_title, deleted_at FROM posts WHERE user_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	posts = []model.TrashedPost{}
+	for rows.Next() {
+		var post model.TrashedPost
+		err = rows.Scan(&post.ID, &post.PostTitle, &post.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return
+}
+
+func (r *postRepository) GetTrashedComments(ctx context.Context, userID int64) (comments []model.TrashedComment, err error) {
+	query := `SELECT id, post_id, comment_content, deleted_at FROM comments WHERE user_id = ? AND deleted_at IS NOT NULL AND (deleted_by IS NULL OR deleted_by = user_id) ORDER BY deleted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	comments = []model.TrashedComment{}
+	for rows.Next() {
+		var comment model.TrashedComment
+		err = rows.Scan(&comment.ID, &comment.PostID, &comment.CommentContent, &comment.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return
+}
+
+// PurgeTrash permanently removes posts and comments trashed before the given time.
+// Rows referencing a purged post are removed first to satisfy the foreign keys.
+// Trashed comments are kept while a reply at any depth below them is not deleted,
+// purging them would turn those replies into top level comments.
+func (r *postRepository) PurgeTrash(ctx context.Context, before time.Time) (purged int64, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	dependentQueries := []string{
+		`DELETE ua FROM user_activities ua JOIN posts p ON ua.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE c FROM comments c JOIN posts p ON c.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE pt FROM post_tags pt JOIN posts p ON pt.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE pr FROM post_revisions pr JOIN posts p ON pr.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE b FROM bookmarks b JOIN posts p ON b.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE vb FROM post_view_buckets vb JOIN posts p ON vb.post_id = p.id WHERE p.deleted_at < ?`,
+		`DELETE tp FROM trending_posts tp JOIN posts p ON tp.post_id = p.id WHERE p.deleted_at < ?`,
+	}
+	for _, query := range dependentQueries {
+		if _, err = tx.ExecContext(ctx, query, before); err != nil {
+			return 0, err
+		}
+	}
+
+	trashQueries := []string{
+		`DELETE FROM comments WHERE deleted_at < ? AND id NOT IN (SELECT id FROM (
+			WITH RECURSIVE ancestors AS (
+				SELECT parent_comment_id AS id FROM comments WHERE deleted_at IS NULL AND parent_comment_id IS NOT NULL
+				UNION
+				SELECT c.parent_comment_id FROM comments c JOIN ancestors a ON c.id = a.id WHERE c.parent_comment_id IS NOT NULL
+			) SELECT id FROM ancestors
+		) kept)`,
+		`DELETE FROM posts WHERE deleted_at < ?`,
+	}
+	for _, query := range trashQueries {
+		var (
+			res      sql.Result
+			affected int64
+		)
+		if res, err = tx.ExecContext(ctx, query, before); err != nil {
+			return 0, err
+		}
+		if affected, err = res.RowsAffected(); err != nil {
+			return 0, err
+		}
+		purged += affected
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestRestorePost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	post := model.Post{ID: 1, UpdatedAt: time.Now(), UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE posts SET deleted_at = NULL, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(post.UpdatedAt, post.UpdatedBy, post.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.RestorePost(ctx, post)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	comment := model.Comment{ID: 1, UpdatedAt: time.Now(), UpdatedBy: "2"}
+
+	mock.ExpectExec(`UPDATE comments SET deleted_at = NULL, deleted_by = NULL, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(comment.UpdatedAt, comment.UpdatedBy, comment.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.RestoreComment(ctx, comment)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrashedPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	userID := int64(2)
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT id, post_title, deleted_at FROM posts WHERE user_id = \? AND deleted_at IS NOT NULL`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_title", "deleted_at"}).
+			AddRow(1, "Title 1", now))
+
+	posts, err := repo.GetTrashedPosts(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TrashedPost{{ID: 1, PostTitle: "Title 1", DeletedAt: now}}, posts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrashedComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	userID := int64(2)
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT id, post_id, comment_content, deleted_at FROM comments WHERE user_id = \? AND deleted_at IS NOT NULL AND \(deleted_by IS NULL OR deleted_by = user_id\)`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "comment_content", "deleted_at"}).
+			AddRow(3, 1, "Old comment", now))
+
+	comments, err := repo.GetTrashedComments(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TrashedComment{{ID: 3, PostID: 1, CommentContent: "Old comment", DeletedAt: now}}, comments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	before := time.Now()
+
+	t.Run("Success PurgeTrash", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE ua FROM user_activities ua JOIN posts p ON ua.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE c FROM comments c JOIN posts p ON c.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE pt FROM post_tags pt JOIN posts p ON pt.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE pr FROM post_revisions pr JOIN posts p ON pr.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(`DELETE b FROM bookmarks b JOIN posts p ON b.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE vb FROM post_view_buckets vb JOIN posts p ON vb.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE tp FROM trending_posts tp JOIN posts p ON tp.post_id = p.id WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM comments WHERE deleted_at < \? AND id NOT IN`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM posts WHERE deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeTrash(ctx, before)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success PurgeTrash - Keeps Comments With Live Replies", func(t *testing.T) {
+		mock.ExpectBegin()
+		for i := 0; i < 7; i++ {
+			mock.ExpectExec(`DELETE .* JOIN posts p ON .* WHERE p.deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		// the ancestors of every comment not deleted are left out of the purge
+		mock.ExpectExec(`DELETE FROM comments WHERE deleted_at < \? AND id NOT IN \(SELECT id FROM \( WITH RECURSIVE ancestors AS \( SELECT parent_comment_id AS id FROM comments WHERE deleted_at IS NULL AND parent_comment_id IS NOT NULL UNION SELECT c.parent_comment_id FROM comments c JOIN ancestors a ON c.id = a.id WHERE c.parent_comment_id IS NOT NULL \) SELECT id FROM ancestors \) kept\)$`).
+			WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM posts WHERE deleted_at < \?`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		purged, err := repo.PurgeTrash(ctx, before)
+		assert.NoError(t, err)
+		assert.Zero(t, purged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail PurgeTrash - Rollback", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE ua FROM user_activities ua`).WithArgs(before).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, err := repo.PurgeTrash(ctx, before)
+		assert.Equal(t, assert.AnError, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// GetEngagements counts the likes, approved comments and views of published
+// posts per hour since the given time
+func (r *trendingRepository) GetEngagements(ctx context.Context, since time.Time) (engagements []model.Engagement, err error) {
+	query := `SELECT e.post_id, e.kind, e.hour, SUM(e.amount) FROM (
+		SELECT post_id, 'like' AS kind, FROM_UNIXTIME(FLOOR(UNIX_TIMESTAMP(updated_at) / 3600) * 3600) AS hour, 1 AS amount
+		FROM user_activities WHERE reaction = 'like' AND updated_at >= ?
+		UNION ALL
+		SELECT post_id, 'comment', FROM_UNIXTIME(FLOOR(UNIX_TIMESTAMP(created_at) / 3600) * 3600), 1
+		FROM comments WHERE status = 'approved' AND deleted_at IS NULL AND created_at >= ?
+		UNION ALL
+		SELECT post_id, 'view', bucket_start, view_count
+		FROM post_view_buckets WHERE bucket_start >= ?
+	) e JOIN posts p ON p.id = e.post_id
+	WHERE p.deleted_at IS NULL AND p.status = 'published'
+	GROUP BY e.post_id, e.kind, e.hour`
+
+	rows, err := r.db.QueryContext(ctx, query, since, since, since)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	engagements = []model.Engagement{}
+	for rows.Next() {
+		var engagement model.Engagement
+		if err = rows.Scan(&engagement.PostID, &engagement.Kind, &engagement.Hour, &engagement.Count); err != nil {
+			return
+		}
+		engagements = append(engagements, engagement)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetPostTags returns the tag names of every given post, keyed by post id
+func (r *trendingRepository) GetPostTags(ctx context.Context, postIDs []int64) (tags map[int64][]string, err error) {
+	return getTagsByPostIDs(ctx, r.db, postIDs)
+}
+
+// ReplaceTrending swaps the cached rankings for the given ones in a single
+// transaction, so readers never see a partial ranking
+func (r *trendingRepository) ReplaceTrending(ctx context.Context, posts []model.TrendingPost, tags []model.TrendingTag, computedAt time.Time) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM trending_posts`); err != nil {
+		return err
+	}
+	if len(posts) > 0 {
+		args := make([]interface{}, 0, len(posts)*3)
+		for _, post := range posts {
+			args = append(args, post.PostID, post.Score, computedAt)
+		}
+		query := `INSERT INTO trending_posts (post_id, score, computed_at) VALUES ` + strings.TrimSuffix(strings.Repeat("(?, ?, ?), ", len(posts)), ", ")
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM trending_tags`); err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		args := make([]interface{}, 0, len(tags)*4)
+		for _, tag := range tags {
+			args = append(args, tag.Name, tag.Score, tag.PostCount, computedAt)
+		}
+		query := `INSERT INTO trending_tags (name, score, post_count, computed_at) VALUES ` + strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?), ", len(tags)), ", ")
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PruneViewBuckets removes the view buckets older than the trending window
+func (r *trendingRepository) PruneViewBuckets(ctx context.Context, before time.Time) (pruned int64, err error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM post_view_buckets WHERE bucket_start < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// GetTrendingPosts pages through the cached post ranking, leaving out posts
+// unpublished or trashed since it was computed and the posts of authors
+// blocking or muted by the viewer
+func (r *trendingRepository) GetTrendingPosts(ctx context.Context, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error) {
+	join := ` JOIN trending_posts tp ON tp.post_id = p.id`
+	where := ` WHERE p.deleted_at IS NULL AND p.status = 'published'` + notBlockedCondition + notMutedCondition
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts p`+join+where, viewerID, viewerID).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := postDetailQuery + join + where + ` ORDER BY tp.score DESC, p.id DESC LIMIT ? OFFSET ?`
+	data, err := queryPostDetails(ctx, r.db, query, viewerID, viewerID, viewerID, viewerID, limit, offset)
+	if err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+// GetTrendingTags pages through the cached tag ranking
+func (r *trendingRepository) GetTrendingTags(ctx context.Context, limit, offset int) (resp model.GetTrendingTagsResponse, err error) {
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM trending_tags`).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT name, score, post_count FROM trending_tags ORDER BY score DESC, name LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.TrendingTag{}
+	for rows.Next() {
+		var tag model.TrendingTag
+		if err = rows.Scan(&tag.Name, &tag.Score, &tag.PostCount); err != nil {
+			return
+		}
+		data = append(data, tag)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
@@ -0,0 +1,167 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetEngagements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &trendingRepository{db: db}
+	ctx := context.Background()
+	since := time.Now().Add(-72 * time.Hour)
+	hour := time.Now().Truncate(time.Hour)
+
+	mock.ExpectQuery(`SELECT e.post_id, e.kind, e.hour, SUM\(e.amount\) FROM \(.* FROM user_activities WHERE reaction = 'like' AND updated_at >= \? UNION ALL .* FROM comments WHERE status = 'approved' AND deleted_at IS NULL AND created_at >= \? UNION ALL .* FROM post_view_buckets WHERE bucket_start >= \? \) e JOIN posts p ON p.id = e.post_id WHERE p.deleted_at IS NULL AND p.status = 'published' GROUP BY e.post_id, e.kind, e.hour`).
+		WithArgs(since, since, since).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "kind", "hour", "amount"}).
+			AddRow(1, "like", hour, 3).
+			AddRow(1, "view", hour, 40))
+
+	engagements, err := repo.GetEngagements(ctx, since)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Engagement{
+		{PostID: 1, Kind: model.EngagementLike, Hour: hour, Count: 3},
+		{PostID: 1, Kind: model.EngagementView, Hour: hour, Count: 40},
+	}, engagements)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceTrending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	posts := []model.TrendingPost{{PostID: 1, Score: 4.5}, {PostID: 2, Score: 1}}
+	tags := []model.TrendingTag{{Name: "go", Score: 5.5, PostCount: 2}}
+
+	t.Run("Success ReplaceTrending", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &trendingRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM trending_posts`).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`INSERT INTO trending_posts \(post_id, score, computed_at\) VALUES \(\?, \?, \?\), \(\?, \?, \?\)$`).
+			WithArgs(int64(1), 4.5, now, int64(2), 1.0, now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM trending_tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO trending_tags \(name, score, post_count, computed_at\) VALUES \(\?, \?, \?, \?\)$`).
+			WithArgs("go", 5.5, 2, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = repo.ReplaceTrending(ctx, posts, tags, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success ReplaceTrending - Empty Ranking", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &trendingRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM trending_posts`).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE FROM trending_tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err = repo.ReplaceTrending(ctx, nil, nil, now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail ReplaceTrending - Rolls Back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &trendingRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM trending_posts`).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`INSERT INTO trending_posts`).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err = repo.ReplaceTrending(ctx, posts, tags, now)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPruneViewBuckets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &trendingRepository{db: db}
+	before := time.Now().Add(-72 * time.Hour)
+
+	mock.ExpectExec(`DELETE FROM post_view_buckets WHERE bucket_start < \?`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	pruned, err := repo.PruneViewBuckets(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), pruned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrendingPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &trendingRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	viewerID := int64(3)
+	where := `JOIN trending_posts tp ON tp.post_id = p.id WHERE p.deleted_at IS NULL AND p.status = 'published' AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\)`
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p `+where+`$`).
+		WithArgs(viewerID, viewerID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT p.id, .* FROM posts p .* `+where+` ORDER BY tp.score DESC, p.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(viewerID, viewerID, viewerID, viewerID, 1, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 1, "author", "Title", "Content", "published", nil, "open", now, now, 8, 2, 120, true, false))
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}).AddRow(5, "go"))
+
+	resp, err := repo.GetTrendingPosts(ctx, viewerID, 1, 0)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, int64(5), resp.Data[0].ID)
+	assert.Equal(t, []string{"go"}, resp.Data[0].PostHashtags)
+	assert.Equal(t, model.Pagination{Limit: 1, Offset: 0, Total: 2, HasMore: true}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrendingTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &trendingRepository{db: db}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM trending_tags`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT name, score, post_count FROM trending_tags ORDER BY score DESC, name LIMIT \? OFFSET \?`).
+		WithArgs(10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "score", "post_count"}).AddRow("go", 5.5, 2))
+
+	resp, err := repo.GetTrendingTags(context.Background(), 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TrendingTag{{Name: "go", Score: 5.5, PostCount: 2}}, resp.Data)
+	assert.Equal(t, model.Pagination{Limit: 10, Offset: 0, Total: 1, HasMore: false}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
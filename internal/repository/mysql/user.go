@@ -7,12 +7,13 @@ import (
 	"github.com/suhriar/blog-mono-api/model"
 )
 
+// GetUser finds the user by email, username or id, deleted accounts are never returned
 func (r *userRepository) GetUser(ctx context.Context, email, username string, userID int64) (user model.User, err error) {
-	query := `SELECT id, email, password, username, created_at, updated_at, created_by, updated_by
-	FROM users WHERE email = ? OR username = ? OR id = ?`
+	query := `SELECT id, email, password, username, email_verified_at, created_at, updated_at, created_by, updated_by
+	FROM users WHERE (email = ? OR username = ? OR id = ?) AND deleted_at IS NULL`
 	row := r.db.QueryRowContext(ctx, query, email, username, userID)
 
-	err = row.Scan(&user.ID, &user.Email, &user.Password, &user.Username, &user.CreatedAt, &user.UpdatedAt, &user.CreatedBy, &user.UpdatedBy)
+	err = row.Scan(&user.ID, &user.Email, &user.Password, &user.Username, &user.EmailVerifiedAt, &user.CreatedAt, &user.UpdatedAt, &user.CreatedBy, &user.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return user, nil
@@ -23,6 +24,13 @@ func (r *userRepository) GetUser(ctx context.Context, email, username string, us
 	return
 }
 
+// IsUserActive reports whether the account exists and was not deleted
+func (r *userRepository) IsUserActive(ctx context.Context, userID int64) (active bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL)`
+	err = r.db.QueryRowContext(ctx, query, userID).Scan(&active)
+	return
+}
+
 func (r *userRepository) CreateUser(ctx context.Context, model model.User) (lastInsertID int64, err error) {
 	query := `INSERT INTO users (email, password, username, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?)`
 	res, err := r.db.ExecContext(ctx, query, model.Email, model.Password, model.Username, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
@@ -37,3 +45,38 @@ func (r *userRepository) CreateUser(ctx context.Context, model model.User) (last
 
 	return
 }
+
+// GetUserProfile returns the profile of the user with the id, or with the
+// username when the id is 0. IsFollowing reports whether the viewer follows them.
+func (r *userRepository) GetUserProfile(ctx context.Context, userID int64, username string, viewerID int64) (profile model.UserProfile, err error) {
+	where := ` WHERE u.id = ?`
+	var arg interface{} = userID
+	if userID == 0 {
+		where = ` WHERE u.username = ?`
+		arg = username
+	}
+
+	query := `SELECT u.id, u.username, u.email, u.display_name, u.bio, u.avatar_url, u.website, u.location, u.created_at,
+		(SELECT COUNT(*) FROM posts p WHERE p.user_id = u.id AND p.status = 'published' AND p.deleted_at IS NULL),
+		(SELECT COUNT(*) FROM follows WHERE followee_id = u.id),
+		(SELECT COUNT(*) FROM follows WHERE follower_id = u.id),
+		EXISTS (SELECT 1 FROM follows WHERE follower_id = ? AND followee_id = u.id)
+	FROM users u` + where + ` AND u.deleted_at IS NULL`
+	row := r.db.QueryRowContext(ctx, query, viewerID, arg)
+
+	err = row.Scan(&profile.ID, &profile.Username, &profile.Email, &profile.DisplayName, &profile.Bio, &profile.AvatarURL, &profile.Website, &profile.Location, &profile.CreatedAt,
+		&profile.PostCount, &profile.FollowerCount, &profile.FollowingCount, &profile.IsFollowing)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return profile, nil
+		}
+		return
+	}
+	return
+}
+
+func (r *userRepository) UpdateUserProfile(ctx context.Context, model model.User) (err error) {
+	query := `UPDATE users SET display_name = ?, bio = ?, avatar_url = ?, website = ?, location = ?, updated_at = ?, updated_by = ? WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, model.DisplayName, model.Bio, model.AvatarURL, model.Website, model.Location, model.UpdatedAt, model.UpdatedBy, model.ID)
+	return err
+}
@@ -8,23 +8,25 @@ import (
 )
 
 func (r *postRepository) GetUserActivity(ctx context.Context, model model.UserActivity) (resp model.UserActivity, err error) {
-	query := `SELECT id, post_id, user_id, is_liked, created_at, updated_at, created_by, updated_by FROM user_activities WHERE post_id = ? AND user_id = ?`
+	query := `SELECT id, post_id, user_id, reaction, created_at, updated_at, created_by, updated_by FROM user_activities WHERE post_id = ? AND user_id = ?`
 
 	row := r.db.QueryRowContext(ctx, query, model.PostID, model.UserID)
 
-	err = row.Scan(&resp.ID, &resp.PostID, &resp.UserID, &resp.IsLiked, &resp.CreatedAt, &resp.UpdatedAt, &resp.CreatedBy, &resp.UpdatedBy)
+	var reaction sql.NullString
+	err = row.Scan(&resp.ID, &resp.PostID, &resp.UserID, &reaction, &resp.CreatedAt, &resp.UpdatedAt, &resp.CreatedBy, &resp.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return resp, nil
 		}
 		return resp, err
 	}
+	resp.Reaction = reaction.String
 	return resp, nil
 }
 
 func (r *postRepository) CreateUserActivity(ctx context.Context, model model.UserActivity) (lastInsertID int64, err error) {
-	query := `INSERT INTO user_activities (post_id, user_id, is_liked, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, model.PostID, model.UserID, model.IsLiked, model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
+	query := `INSERT INTO user_activities (post_id, user_id, reaction, created_at, updated_at, created_by, updated_by) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, model.PostID, model.UserID, nullString(model.Reaction), model.CreatedAt, model.UpdatedAt, model.CreatedBy, model.UpdatedBy)
 	if err != nil {
 		return
 	}
@@ -38,22 +40,112 @@ func (r *postRepository) CreateUserActivity(ctx context.Context, model model.Use
 }
 
 func (r *postRepository) UpdateUserActivity(ctx context.Context, req model.UserActivity) (err error) {
-	query := `UPDATE user_activities SET is_liked = ?, updated_at = ?, updated_by = ? WHERE post_id = ? AND user_id = ?`
-	_, err = r.db.ExecContext(ctx, query, req.IsLiked, req.UpdatedAt, req.UpdatedBy, req.PostID, req.UserID)
+	query := `UPDATE user_activities SET reaction = ?, updated_at = ?, updated_by = ? WHERE post_id = ? AND user_id = ?`
+	_, err = r.db.ExecContext(ctx, query, nullString(req.Reaction), req.UpdatedAt, req.UpdatedBy, req.PostID, req.UserID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *postRepository) CountLikeByPostID(ctx context.Context, postID int64) (count int, err error) {
-	query := `SELECT COUNT(id) FROM user_activities WHERE post_id = ? AND is_liked = true`
+// GetPostReactions counts the reactions to the post per type and returns the
+// reaction of the viewer, empty when the viewer did not react
+func (r *postRepository) GetPostReactions(ctx context.Context, postID, viewerID int64) (counts map[string]int, viewerReaction string, err error) {
+	query := `SELECT reaction, COUNT(*), MAX(user_id = ?) FROM user_activities WHERE post_id = ? AND reaction IS NOT NULL GROUP BY reaction`
 
-	row := r.db.QueryRowContext(ctx, query, postID)
+	rows, err := r.db.QueryContext(ctx, query, viewerID, postID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	counts = map[string]int{}
+	for rows.Next() {
+		var (
+			reaction string
+			count    int
+			byViewer bool
+		)
+		if err = rows.Scan(&reaction, &count, &byViewer); err != nil {
+			return
+		}
+		counts[reaction] = count
+		if byViewer {
+			viewerReaction = reaction
+		}
+	}
+	err = rows.Err()
+	return
+}
+
+// nullString stores an empty string as NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// GetPostLikes lists the users who liked the post, most recent like first
+func (r *postRepository) GetPostLikes(ctx context.Context, postID int64, limit, offset int) (resp model.GetPostLikesResponse, err error) {
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_activities WHERE post_id = ? AND reaction = 'like'`, postID).Scan(&total)
+	if err != nil {
+		return
+	}
+
+	query := `SELECT u.id, u.username, ua.updated_at FROM user_activities ua JOIN users u ON ua.user_id = u.id
+	WHERE ua.post_id = ? AND ua.reaction = 'like' ORDER BY ua.updated_at DESC, ua.id DESC LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, postID, limit, offset)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	data := []model.PostLike{}
+	for rows.Next() {
+		var like model.PostLike
+		if err = rows.Scan(&like.UserID, &like.Username, &like.LikedAt); err != nil {
+			return
+		}
+		data = append(data, like)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
+	return
+}
+
+// GetLikedPosts lists the posts liked by the user that the viewer may see, most
+// recent like first, leaving out authors blocking or muted by the viewer
+func (r *postRepository) GetLikedPosts(ctx context.Context, userID, viewerID int64, limit, offset int) (resp model.GetAllPostResponse, err error) {
+	join := ` JOIN user_activities ul ON ul.post_id = p.id AND ul.user_id = ? AND ul.reaction = 'like'`
+	where := ` WHERE p.deleted_at IS NULL AND (p.status = 'published' OR p.user_id = ?)` + notBlockedCondition + notMutedCondition
 
-	err = row.Scan(&count)
+	var total int
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts p`+join+where, userID, viewerID, viewerID, viewerID).Scan(&total)
 	if err != nil {
 		return
 	}
+
+	query := postDetailQuery + join + where + ` ORDER BY ul.updated_at DESC, p.id DESC LIMIT ? OFFSET ?`
+	data, err := queryPostDetails(ctx, r.db, query, viewerID, viewerID, userID, viewerID, viewerID, viewerID, limit, offset)
+	if err != nil {
+		return
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+len(data) < total,
+	}
 	return
 }
@@ -19,14 +19,15 @@ func TestGetUserActivity(t *testing.T) {
 	ctx := context.Background()
 	userActivity := model.UserActivity{PostID: 1, UserID: 2}
 
-	mock.ExpectQuery(`SELECT id, post_id, user_id, is_liked, created_at, updated_at, created_by, updated_by FROM user_activities WHERE post_id = \? AND user_id = \?`).
+	mock.ExpectQuery(`SELECT id, post_id, user_id, reaction, created_at, updated_at, created_by, updated_by FROM user_activities WHERE post_id = \? AND user_id = \?`).
 		WithArgs(userActivity.PostID, userActivity.UserID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "user_id", "is_liked", "created_at", "updated_at", "created_by", "updated_by"}).
-			AddRow(1, 1, 2, true, time.Now(), time.Now(), "test_user", "test_user"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "user_id", "reaction", "created_at", "updated_at", "created_by", "updated_by"}).
+			AddRow(1, 1, 2, "love", time.Now(), time.Now(), "test_user", "test_user"))
 
 	resp, err := repo.GetUserActivity(ctx, userActivity)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), resp.ID)
+	assert.Equal(t, "love", resp.Reaction)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -37,10 +38,10 @@ func TestCreateUserActivity(t *testing.T) {
 
 	repo := &postRepository{db: db}
 	ctx := context.Background()
-	userActivity := model.UserActivity{PostID: 1, UserID: 2, IsLiked: true, CreatedAt: time.Now(), UpdatedAt: time.Now(), CreatedBy: "test_user", UpdatedBy: "test_user"}
+	userActivity := model.UserActivity{PostID: 1, UserID: 2, Reaction: model.ReactionLike, CreatedAt: time.Now(), UpdatedAt: time.Now(), CreatedBy: "test_user", UpdatedBy: "test_user"}
 
 	mock.ExpectExec(`INSERT INTO user_activities`).
-		WithArgs(userActivity.PostID, userActivity.UserID, userActivity.IsLiked, userActivity.CreatedAt, userActivity.UpdatedAt, userActivity.CreatedBy, userActivity.UpdatedBy).
+		WithArgs(userActivity.PostID, userActivity.UserID, nullString(userActivity.Reaction), userActivity.CreatedAt, userActivity.UpdatedAt, userActivity.CreatedBy, userActivity.UpdatedBy).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	lastInsertID, err := repo.CreateUserActivity(ctx, userActivity)
@@ -56,10 +57,10 @@ func TestUpdateUserActivity(t *testing.T) {
 
 	repo := &postRepository{db: db}
 	ctx := context.Background()
-	userActivity := model.UserActivity{PostID: 1, UserID: 2, IsLiked: false, UpdatedAt: time.Now(), UpdatedBy: "test_user"}
+	userActivity := model.UserActivity{PostID: 1, UserID: 2, UpdatedAt: time.Now(), UpdatedBy: "test_user"}
 
-	mock.ExpectExec(`UPDATE user_activities SET is_liked = \?, updated_at = \?, updated_by = \? WHERE post_id = \? AND user_id = \?`).
-		WithArgs(userActivity.IsLiked, userActivity.UpdatedAt, userActivity.UpdatedBy, userActivity.PostID, userActivity.UserID).
+	mock.ExpectExec(`UPDATE user_activities SET reaction = \?, updated_at = \?, updated_by = \? WHERE post_id = \? AND user_id = \?`).
+		WithArgs(nil, userActivity.UpdatedAt, userActivity.UpdatedBy, userActivity.PostID, userActivity.UserID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err = repo.UpdateUserActivity(ctx, userActivity)
@@ -67,21 +68,80 @@ func TestUpdateUserActivity(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestCountLikeByPostID(t *testing.T) {
+func TestGetPostReactions(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	repo := &postRepository{db: db}
 	ctx := context.Background()
-	postID := int64(1)
 
-	mock.ExpectQuery(`SELECT COUNT\(id\) FROM user_activities WHERE post_id = \? AND is_liked = true`).
-		WithArgs(postID).
-		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`SELECT reaction, COUNT\(\*\), MAX\(user_id = \?\) FROM user_activities WHERE post_id = \? AND reaction IS NOT NULL GROUP BY reaction`).
+		WithArgs(int64(2), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"reaction", "count", "by_viewer"}).
+			AddRow("like", 3, 0).
+			AddRow("love", 1, 1))
 
-	count, err := repo.CountLikeByPostID(ctx, postID)
+	counts, viewerReaction, err := repo.GetPostReactions(ctx, 1, 2)
 	assert.NoError(t, err)
-	assert.Equal(t, 5, count)
+	assert.Equal(t, map[string]int{"like": 3, "love": 1}, counts)
+	assert.Equal(t, "love", viewerReaction)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPostLikes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM user_activities WHERE post_id = \? AND reaction = 'like'`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT u.id, u.username, ua.updated_at FROM user_activities ua JOIN users u ON ua.user_id = u.id WHERE ua.post_id = \? AND ua.reaction = 'like' ORDER BY ua.updated_at DESC, ua.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(int64(1), 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "updated_at"}).
+			AddRow(4, "user4", now).
+			AddRow(2, "user2", now))
+
+	resp, err := repo.GetPostLikes(ctx, 1, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.PostLike{{UserID: 4, Username: "user4", LikedAt: now}, {UserID: 2, Username: "user2", LikedAt: now}}, resp.Data)
+	assert.Equal(t, model.Pagination{Limit: 2, Offset: 0, Total: 3, HasMore: true}, resp.Pagination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLikedPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &postRepository{db: db}
+	ctx := context.Background()
+	now := time.Now()
+	userID := int64(2)
+	viewerID := int64(3)
+	columns := []string{"id", "user_id", "username", "post_title", "post_content", "status", "publish_at", "comment_mode", "created_at", "updated_at", "like_count", "comment_count", "view_count", "is_liked", "is_bookmarked"}
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts p JOIN user_activities ul ON ul.post_id = p.id AND ul.user_id = \? AND ul.reaction = 'like' WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\)`).
+		WithArgs(userID, viewerID, viewerID, viewerID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT p.id, .* vb.user_id = \? JOIN user_activities ul ON ul.post_id = p.id AND ul.user_id = \? AND ul.reaction = 'like' WHERE p.deleted_at IS NULL AND \(p.status = 'published' OR p.user_id = \?\) AND p.user_id NOT IN \(SELECT blocker_id FROM blocks WHERE blocked_id = \?\) AND p.user_id NOT IN \(SELECT muted_id FROM mutes WHERE muter_id = \?\) ORDER BY ul.updated_at DESC, p.id DESC LIMIT \? OFFSET \?`).
+		WithArgs(viewerID, viewerID, userID, viewerID, viewerID, viewerID, 10, 10).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 1, "author", "Title", "Content", "published", nil, "open", now, now, 4, 0, 0, false, false))
+	mock.ExpectQuery(`SELECT pt.post_id, t.name FROM post_tags pt`).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"post_id", "name"}))
+
+	resp, err := repo.GetLikedPosts(ctx, userID, viewerID, 10, 10)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, int64(5), resp.Data[0].ID)
+	assert.Equal(t, 4, resp.Data[0].LikeCount)
+	assert.Equal(t, model.Pagination{Limit: 10, Offset: 10, Total: 1, HasMore: false}, resp.Pagination)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -35,10 +35,10 @@ func TestGetUser(t *testing.T) {
 	}
 
 	// Mock DB response
-	rows := sqlmock.NewRows([]string{"id", "email", "password", "username", "created_at", "updated_at", "created_by", "updated_by"}).
-		AddRow(mockUser.ID, mockUser.Email, mockUser.Password, mockUser.Username, mockUser.CreatedAt, mockUser.UpdatedAt, mockUser.CreatedBy, mockUser.UpdatedBy)
+	rows := sqlmock.NewRows([]string{"id", "email", "password", "username", "email_verified_at", "created_at", "updated_at", "created_by", "updated_by"}).
+		AddRow(mockUser.ID, mockUser.Email, mockUser.Password, mockUser.Username, nil, mockUser.CreatedAt, mockUser.UpdatedAt, mockUser.CreatedBy, mockUser.UpdatedBy)
 
-	mock.ExpectQuery(`SELECT id, email, password, username, created_at, updated_at, created_by, updated_by FROM users`).
+	mock.ExpectQuery(`SELECT id, email, password, username, email_verified_at, created_at, updated_at, created_by, updated_by FROM users WHERE \(email = \? OR username = \? OR id = \?\) AND deleted_at IS NULL`).
 		WithArgs(email, username, userID).
 		WillReturnRows(rows)
 
@@ -53,6 +53,24 @@ func TestGetUser(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestIsUserActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM users WHERE id = \? AND deleted_at IS NULL\)`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+
+	active, err := repo.IsUserActive(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // Test CreateUser
 func TestCreateUser(t *testing.T) {
 	db, mock, err := sqlmock.New()
@@ -87,3 +105,74 @@ func TestCreateUser(t *testing.T) {
 	// Ensure all expectations were met
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetUserProfile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{"id", "username", "email", "display_name", "bio", "avatar_url", "website", "location", "created_at", "post_count", "follower_count", "following_count", "is_following"}
+	counts := `SELECT u.id, u.username, u.email, u.display_name, u.bio, u.avatar_url, u.website, u.location, u.created_at, \(SELECT COUNT\(\*\) FROM posts p WHERE p.user_id = u.id AND p.status = 'published' AND p.deleted_at IS NULL\), \(SELECT COUNT\(\*\) FROM follows WHERE followee_id = u.id\), \(SELECT COUNT\(\*\) FROM follows WHERE follower_id = u.id\), EXISTS \(SELECT 1 FROM follows WHERE follower_id = \? AND followee_id = u.id\) FROM users u`
+
+	t.Run("Success GetUserProfile - By Username", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectQuery(counts+` WHERE u.username = \? AND u.deleted_at IS NULL$`).
+			WithArgs(int64(1), "alice").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, "alice", "alice@example.com", "Alice", "Writes about Go", "", "https://alice.dev", "Jakarta", now, 4, 10, 2, true))
+
+		profile, err := repo.GetUserProfile(ctx, 0, "alice", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, model.UserProfile{
+			ID:             2,
+			Username:       "alice",
+			Email:          "alice@example.com",
+			DisplayName:    "Alice",
+			Bio:            "Writes about Go",
+			Website:        "https://alice.dev",
+			Location:       "Jakarta",
+			CreatedAt:      now,
+			PostCount:      4,
+			FollowerCount:  10,
+			FollowingCount: 2,
+			IsFollowing:    true,
+		}, profile)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetUserProfile - By ID Not Found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectQuery(counts+` WHERE u.id = \? AND u.deleted_at IS NULL$`).
+			WithArgs(int64(1), int64(1)).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		profile, err := repo.GetUserProfile(ctx, 1, "", 1)
+		assert.NoError(t, err)
+		assert.Empty(t, profile)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	now := time.Now()
+	user := model.User{ID: 1, DisplayName: "Alice", Bio: "Hi", AvatarURL: "https://cdn.example.com/a.png", Website: "https://alice.dev", Location: "Jakarta", UpdatedAt: now, UpdatedBy: "1"}
+
+	mock.ExpectExec(`UPDATE users SET display_name = \?, bio = \?, avatar_url = \?, website = \?, location = \?, updated_at = \?, updated_by = \? WHERE id = \?`).
+		WithArgs(user.DisplayName, user.Bio, user.AvatarURL, user.Website, user.Location, now, "1", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateUserProfile(context.Background(), user)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
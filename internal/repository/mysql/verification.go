@@ -0,0 +1,63 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func (r *userRepository) CreateEmailVerification(ctx context.Context, model model.EmailVerification) (err error) {
+	query := `INSERT INTO email_verifications (user_id, token_hash, expired_at, created_at) VALUES (?, ?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, model.UserID, model.TokenHash, model.ExpiredAt, model.CreatedAt)
+	return err
+}
+
+// GetLatestEmailVerification returns the verification most recently sent to
+// the user, used or not
+func (r *userRepository) GetLatestEmailVerification(ctx context.Context, userID int64) (verification model.EmailVerification, err error) {
+	query := `SELECT id, user_id, expired_at, used_at, created_at FROM email_verifications WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT 1`
+	row := r.db.QueryRowContext(ctx, query, userID)
+
+	err = row.Scan(&verification.ID, &verification.UserID, &verification.ExpiredAt, &verification.UsedAt, &verification.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return verification, nil
+		}
+		return
+	}
+	return
+}
+
+// VerifyEmail uses the unexpired verification token with the hash to mark the
+// email of its user as verified, along with every other pending token of the
+// user. It returns the id of the user, or 0 when there is no such token.
+func (r *userRepository) VerifyEmail(ctx context.Context, tokenHash string, now time.Time) (userID int64, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `SELECT user_id FROM email_verifications WHERE token_hash = ? AND used_at IS NULL AND expired_at >= ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, tokenHash, now).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, tx.Rollback()
+		}
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE users SET email_verified_at = ? WHERE id = ? AND email_verified_at IS NULL`, now, userID); err != nil {
+		return 0, err
+	}
+	if _, err = tx.ExecContext(ctx, `UPDATE email_verifications SET used_at = ? WHERE user_id = ? AND used_at IS NULL`, now, userID); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestCreateEmailVerification(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &userRepository{db: db}
+	now := time.Now()
+	verification := model.EmailVerification{UserID: 1, TokenHash: "abc", ExpiredAt: now.Add(24 * time.Hour), CreatedAt: now}
+
+	mock.ExpectExec(`INSERT INTO email_verifications \(user_id, token_hash, expired_at, created_at\) VALUES \(\?, \?, \?, \?\)`).
+		WithArgs(int64(1), "abc", verification.ExpiredAt, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.CreateEmailVerification(context.Background(), verification)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLatestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	query := `SELECT id, user_id, expired_at, used_at, created_at FROM email_verifications WHERE user_id = \? ORDER BY created_at DESC, id DESC LIMIT 1`
+
+	t.Run("Success GetLatestEmailVerification", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectQuery(query).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "expired_at", "used_at", "created_at"}).
+				AddRow(3, 1, now.Add(24*time.Hour), nil, now))
+
+		verification, err := repo.GetLatestEmailVerification(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, model.EmailVerification{ID: 3, UserID: 1, ExpiredAt: now.Add(24 * time.Hour), CreatedAt: now}, verification)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success GetLatestEmailVerification - None Sent", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectQuery(query).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "expired_at", "used_at", "created_at"}))
+
+		verification, err := repo.GetLatestEmailVerification(ctx, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, verification)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	selectToken := `SELECT user_id FROM email_verifications WHERE token_hash = \? AND used_at IS NULL AND expired_at >= \? FOR UPDATE`
+
+	t.Run("Success VerifyEmail", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectToken).
+			WithArgs("abc", now).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(7))
+		mock.ExpectExec(`UPDATE users SET email_verified_at = \? WHERE id = \? AND email_verified_at IS NULL`).
+			WithArgs(now, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE email_verifications SET used_at = \? WHERE user_id = \? AND used_at IS NULL`).
+			WithArgs(now, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		userID, err := repo.VerifyEmail(ctx, "abc", now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), userID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success VerifyEmail - Unknown Token", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &userRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectToken).
+			WithArgs("abc", now).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+		mock.ExpectRollback()
+
+		userID, err := repo.VerifyEmail(ctx, "abc", now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), userID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
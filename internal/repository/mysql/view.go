@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IncrementViewCounts adds the buffered views to the view counters of the posts
+// and to the hourly view buckets the trending ranking decays. Views of posts
+// deleted since they were buffered are dropped.
+func (r *postRepository) IncrementViewCounts(ctx context.Context, counts map[int64]int64, at time.Time) (err error) {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int64, 0, len(counts))
+	for postID := range counts {
+		postIDs = append(postIDs, postID)
+	}
+	sort.Slice(postIDs, func(i, j int) bool { return postIDs[i] < postIDs[j] })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	hour := at.UTC().Truncate(time.Hour)
+	cases := make([]interface{}, 0, len(postIDs)*2)
+	ids := make([]interface{}, 0, len(postIDs))
+	buckets := make([]interface{}, 0, len(postIDs)*2+1)
+	buckets = append(buckets, hour)
+	for _, postID := range postIDs {
+		cases = append(cases, postID, counts[postID])
+		ids = append(ids, postID)
+		buckets = append(buckets, postID, counts[postID])
+	}
+
+	query := `UPDATE posts SET view_count = view_count + CASE id` + strings.Repeat(` WHEN ? THEN ?`, len(postIDs)) + ` END
+	WHERE id IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(postIDs)), ", ") + `)`
+	if _, err = tx.ExecContext(ctx, query, append(cases, ids...)...); err != nil {
+		return err
+	}
+
+	// joining posts skips the ids whose post is gone, the bucket foreign key
+	// would otherwise fail the whole flush
+	query = `INSERT INTO post_view_buckets (post_id, bucket_start, view_count)
+	SELECT p.id, ?, v.views FROM posts p JOIN (SELECT ? AS post_id, ? AS views` + strings.Repeat(` UNION ALL SELECT ?, ?`, len(postIDs)-1) + `) v ON v.post_id = p.id
+	ON DUPLICATE KEY UPDATE view_count = post_view_buckets.view_count + VALUES(view_count)`
+	if _, err = tx.ExecContext(ctx, query, buckets...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
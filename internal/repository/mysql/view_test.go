@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrementViewCounts(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2024, 5, 1, 10, 42, 0, 0, time.UTC)
+	hour := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Success IncrementViewCounts", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE posts SET view_count = view_count \+ CASE id WHEN \? THEN \? WHEN \? THEN \? END WHERE id IN \(\?, \?\)`).
+			WithArgs(int64(2), int64(5), int64(7), int64(1), int64(2), int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`INSERT INTO post_view_buckets \(post_id, bucket_start, view_count\) SELECT p.id, \?, v.views FROM posts p JOIN \(SELECT \? AS post_id, \? AS views UNION ALL SELECT \?, \?\) v ON v.post_id = p.id ON DUPLICATE KEY UPDATE view_count = post_view_buckets.view_count \+ VALUES\(view_count\)`).
+			WithArgs(hour, int64(2), int64(5), int64(7), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err = repo.IncrementViewCounts(ctx, map[int64]int64{7: 1, 2: 5}, at)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success IncrementViewCounts - Nothing To Write", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		err = repo.IncrementViewCounts(ctx, map[int64]int64{}, at)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fail IncrementViewCounts - Rollback", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := &postRepository{db: db}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE posts SET view_count`).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err = repo.IncrementViewCounts(ctx, map[int64]int64{1: 1}, at)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
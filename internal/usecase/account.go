@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ExportMe collects the profile of the user and everything they created or
+// reacted to
+func (u *userUsecase) ExportMe(ctx context.Context, userID int64) (export model.UserExport, err error) {
+	profile, err := u.GetMe(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	export, err = u.userRepository.GetUserExport(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user export from database")
+		return
+	}
+
+	export.ExportedAt = time.Now()
+	export.Profile = profile
+	return
+}
+
+// DeleteMe deletes the account after checking its password. The posts and
+// comments of the user are anonymized or removed depending on the deletion
+// policy, their private data is removed either way.
+func (u *userUsecase) DeleteMe(ctx context.Context, userID int64, req model.DeleteAccountRequest) (err error) {
+	user, err := u.userRepository.GetUser(ctx, "", "", userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if user.ID == 0 {
+		return model.ErrUserNotFound
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return fmt.Errorf("%w: password is invalid", model.ErrInvalidInput)
+	}
+
+	if u.deletionPolicy == model.AccountDeletionRemove {
+		err = u.userRepository.DeleteUser(ctx, userID)
+	} else {
+		err = u.userRepository.AnonymizeUser(ctx, userID, time.Now())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error delete user from database")
+		return err
+	}
+	return nil
+}
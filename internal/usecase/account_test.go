@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestExportMe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success ExportMe", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+		profile := model.UserProfile{ID: 1, Username: "alice", Email: "alice@example.com"}
+		export := model.UserExport{Posts: []model.Post{{ID: 10}}}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(profile, nil)
+		mockRepo.On("GetUserExport", ctx, int64(1)).Return(export, nil)
+
+		res, err := usecase.ExportMe(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, profile, res.Profile)
+		assert.Equal(t, export.Posts, res.Posts)
+		assert.False(t, res.ExportedAt.IsZero())
+	})
+
+	t.Run("Fail ExportMe - User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(model.UserProfile{}, nil)
+
+		_, err := usecase.ExportMe(ctx, 1)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "GetUserExport")
+	})
+}
+
+func TestDeleteMe(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := model.User{ID: 1, Email: "alice@example.com", Password: string(hash)}
+	req := model.DeleteAccountRequest{Password: "password"}
+
+	t.Run("Success DeleteMe - Anonymize", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo, deletionPolicy: model.AccountDeletionAnonymize}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+		mockRepo.On("AnonymizeUser", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+		err := usecase.DeleteMe(ctx, 1, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DeleteUser")
+	})
+
+	t.Run("Success DeleteMe - Remove", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo, deletionPolicy: model.AccountDeletionRemove}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+		mockRepo.On("DeleteUser", ctx, int64(1)).Return(nil)
+
+		err := usecase.DeleteMe(ctx, 1, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "AnonymizeUser")
+	})
+
+	t.Run("Fail DeleteMe - Wrong Password", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+
+		err := usecase.DeleteMe(ctx, 1, model.DeleteAccountRequest{Password: "guess"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "AnonymizeUser")
+		mockRepo.AssertNotCalled(t, "DeleteUser")
+	})
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// BlockUser blocks the user, which also ends the follows between both users
+func (u *blockUsecase) BlockUser(ctx context.Context, blockedID, userID int64) (err error) {
+	if blockedID == userID {
+		return fmt.Errorf("%w: you cannot block yourself", model.ErrInvalidInput)
+	}
+
+	if err = checkUserExists(ctx, u.userRepository, blockedID); err != nil {
+		return
+	}
+
+	err = u.blockRepository.CreateBlock(ctx, model.Block{
+		BlockerID: userID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error create block to database")
+		return
+	}
+	return
+}
+
+func (u *blockUsecase) UnblockUser(ctx context.Context, blockedID, userID int64) (err error) {
+	deleted, err := u.blockRepository.DeleteBlock(ctx, userID, blockedID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete block from database")
+		return
+	}
+	if !deleted {
+		return model.ErrBlockNotFound
+	}
+	return
+}
+
+func (u *blockUsecase) GetBlockedUsers(ctx context.Context, userID int64, pageSize, pageIndex int) (users model.GetRestrictedUsersResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	users, err = u.blockRepository.GetBlockedUsers(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get blocked users from database")
+		return
+	}
+	return
+}
+
+func (u *blockUsecase) MuteUser(ctx context.Context, mutedID, userID int64) (err error) {
+	if mutedID == userID {
+		return fmt.Errorf("%w: you cannot mute yourself", model.ErrInvalidInput)
+	}
+
+	if err = checkUserExists(ctx, u.userRepository, mutedID); err != nil {
+		return
+	}
+
+	err = u.blockRepository.CreateMute(ctx, model.Mute{
+		MuterID:   userID,
+		MutedID:   mutedID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error create mute to database")
+		return
+	}
+	return
+}
+
+func (u *blockUsecase) UnmuteUser(ctx context.Context, mutedID, userID int64) (err error) {
+	deleted, err := u.blockRepository.DeleteMute(ctx, userID, mutedID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete mute from database")
+		return
+	}
+	if !deleted {
+		return model.ErrMuteNotFound
+	}
+	return
+}
+
+func (u *blockUsecase) GetMutedUsers(ctx context.Context, userID int64, pageSize, pageIndex int) (users model.GetRestrictedUsersResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	users, err = u.blockRepository.GetMutedUsers(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get muted users from database")
+		return
+	}
+	return
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestBlockUser(t *testing.T) {
+	ctx := context.Background()
+	blockedID := int64(2)
+	userID := int64(1)
+
+	t.Run("Success BlockUser", func(t *testing.T) {
+		mockBlockRepo := new(mocks.MockBlockRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &blockUsecase{blockRepository: mockBlockRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", blockedID).Return(model.User{ID: blockedID}, nil)
+		mockBlockRepo.On("CreateBlock", ctx, mock.MatchedBy(func(block model.Block) bool {
+			return block.BlockerID == userID && block.BlockedID == blockedID
+		})).Return(nil)
+
+		err := usecase.BlockUser(ctx, blockedID, userID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockBlockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail BlockUser - Self", func(t *testing.T) {
+		usecase := &blockUsecase{}
+
+		err := usecase.BlockUser(ctx, userID, userID)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+
+	t.Run("Fail BlockUser - User Not Found", func(t *testing.T) {
+		mockBlockRepo := new(mocks.MockBlockRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &blockUsecase{blockRepository: mockBlockRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", blockedID).Return(model.User{}, nil)
+
+		err := usecase.BlockUser(ctx, blockedID, userID)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+		mockBlockRepo.AssertNotCalled(t, "CreateBlock")
+	})
+}
+
+func TestUnblockUser(t *testing.T) {
+	ctx := context.Background()
+	mockBlockRepo := new(mocks.MockBlockRepository)
+	usecase := &blockUsecase{blockRepository: mockBlockRepo}
+
+	mockBlockRepo.On("DeleteBlock", ctx, int64(1), int64(2)).Return(false, nil)
+
+	err := usecase.UnblockUser(ctx, 2, 1)
+
+	assert.ErrorIs(t, err, model.ErrBlockNotFound)
+}
+
+func TestMuteUser(t *testing.T) {
+	ctx := context.Background()
+	mockBlockRepo := new(mocks.MockBlockRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	usecase := &blockUsecase{blockRepository: mockBlockRepo, userRepository: mockUserRepo}
+
+	mockUserRepo.On("GetUser", ctx, "", "", int64(2)).Return(model.User{ID: 2}, nil)
+	mockBlockRepo.On("CreateMute", ctx, mock.MatchedBy(func(mute model.Mute) bool {
+		return mute.MuterID == 1 && mute.MutedID == 2
+	})).Return(nil)
+
+	err := usecase.MuteUser(ctx, 2, 1)
+
+	assert.NoError(t, err)
+	mockBlockRepo.AssertExpectations(t)
+}
+
+func TestUnmuteUser(t *testing.T) {
+	ctx := context.Background()
+	mockBlockRepo := new(mocks.MockBlockRepository)
+	usecase := &blockUsecase{blockRepository: mockBlockRepo}
+
+	mockBlockRepo.On("DeleteMute", ctx, int64(1), int64(2)).Return(true, nil)
+
+	err := usecase.UnmuteUser(ctx, 2, 1)
+
+	assert.NoError(t, err)
+	mockBlockRepo.AssertExpectations(t)
+}
+
+func TestGetMutedUsers(t *testing.T) {
+	ctx := context.Background()
+	mockBlockRepo := new(mocks.MockBlockRepository)
+	usecase := &blockUsecase{blockRepository: mockBlockRepo}
+
+	expected := model.GetRestrictedUsersResponse{Data: []model.RestrictedUser{{UserID: 2, Username: "loud"}}}
+	mockBlockRepo.On("GetMutedUsers", ctx, int64(1), defaultPageSize, 0).Return(expected, nil)
+
+	users, err := usecase.GetMutedUsers(ctx, 1, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, users)
+	mockBlockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+const maxCollectionLength = 100
+
+// BookmarkPost saves a post the user may see to the named collection, moving
+// it there when it is already bookmarked
+func (u *bookmarkUsecase) BookmarkPost(ctx context.Context, postID, userID int64, req model.BookmarkRequest) (err error) {
+	collection, err := normalizeCollection(req.Collection)
+	if err != nil {
+		return
+	}
+
+	if _, err = getVisiblePost(ctx, u.postRepository, postID, userID); err != nil {
+		return
+	}
+
+	now := time.Now()
+	err = u.bookmarkRepository.UpsertBookmark(ctx, model.Bookmark{
+		UserID:     userID,
+		PostID:     postID,
+		Collection: collection,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error upsert bookmark to database")
+		return
+	}
+	return
+}
+
+func (u *bookmarkUsecase) RemoveBookmark(ctx context.Context, postID, userID int64) (err error) {
+	deleted, err := u.bookmarkRepository.DeleteBookmark(ctx, userID, postID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete bookmark from database")
+		return
+	}
+	if !deleted {
+		return model.ErrBookmarkNotFound
+	}
+	return
+}
+
+// GetBookmarks pages through the bookmarks of the user, restricted to one
+// collection when collection is set
+func (u *bookmarkUsecase) GetBookmarks(ctx context.Context, userID int64, collection *string, pageSize, pageIndex int) (bookmarks model.GetBookmarksResponse, err error) {
+	if collection != nil {
+		name, normalizeErr := normalizeCollection(*collection)
+		if normalizeErr != nil {
+			return bookmarks, normalizeErr
+		}
+		collection = &name
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	bookmarks, err = u.bookmarkRepository.GetBookmarks(ctx, userID, collection, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get bookmarks from database")
+		return
+	}
+	return
+}
+
+func (u *bookmarkUsecase) GetBookmarkCollections(ctx context.Context, userID int64) (collections []model.BookmarkCollection, err error) {
+	collections, err = u.bookmarkRepository.GetBookmarkCollections(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get bookmark collections from database")
+		return
+	}
+	return
+}
+
+// normalizeCollection trims the collection name, an empty name selects the
+// default collection
+func normalizeCollection(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxCollectionLength {
+		return "", fmt.Errorf("%w: collection name is longer than %d characters", model.ErrInvalidInput, maxCollectionLength)
+	}
+	return name, nil
+}
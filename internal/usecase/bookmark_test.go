@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestBookmarkPost(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(2)
+
+	t.Run("Success BookmarkPost", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		mockPostRepo := new(mocks.MockPostRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo, postRepository: mockPostRepo}
+
+		mockPostRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockPostRepo.On("IsBlocked", ctx, int64(3), userID).Return(false, nil)
+		mockBookmarkRepo.On("UpsertBookmark", ctx, mock.MatchedBy(func(bookmark model.Bookmark) bool {
+			return bookmark.PostID == postID && bookmark.UserID == userID && bookmark.Collection == "later"
+		})).Return(nil)
+
+		err := usecase.BookmarkPost(ctx, postID, userID, model.BookmarkRequest{Collection: "  later "})
+
+		assert.NoError(t, err)
+		mockPostRepo.AssertExpectations(t)
+		mockBookmarkRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail BookmarkPost - Post Not Visible", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		mockPostRepo := new(mocks.MockPostRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo, postRepository: mockPostRepo}
+
+		mockPostRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusDraft}, nil)
+
+		err := usecase.BookmarkPost(ctx, postID, userID, model.BookmarkRequest{})
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockBookmarkRepo.AssertNotCalled(t, "UpsertBookmark")
+	})
+
+	t.Run("Fail BookmarkPost - Blocked By Author", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		mockPostRepo := new(mocks.MockPostRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo, postRepository: mockPostRepo}
+
+		mockPostRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockPostRepo.On("IsBlocked", ctx, int64(3), userID).Return(true, nil)
+
+		err := usecase.BookmarkPost(ctx, postID, userID, model.BookmarkRequest{})
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockBookmarkRepo.AssertNotCalled(t, "UpsertBookmark")
+	})
+
+	t.Run("Fail BookmarkPost - Collection Too Long", func(t *testing.T) {
+		usecase := &bookmarkUsecase{}
+
+		err := usecase.BookmarkPost(ctx, postID, userID, model.BookmarkRequest{Collection: strings.Repeat("a", maxCollectionLength+1)})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
+
+func TestRemoveBookmark(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success RemoveBookmark", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo}
+
+		mockBookmarkRepo.On("DeleteBookmark", ctx, int64(2), int64(1)).Return(true, nil)
+
+		err := usecase.RemoveBookmark(ctx, 1, 2)
+
+		assert.NoError(t, err)
+		mockBookmarkRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RemoveBookmark - Not Bookmarked", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo}
+
+		mockBookmarkRepo.On("DeleteBookmark", ctx, int64(2), int64(1)).Return(false, nil)
+
+		err := usecase.RemoveBookmark(ctx, 1, 2)
+
+		assert.ErrorIs(t, err, model.ErrBookmarkNotFound)
+		mockBookmarkRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetBookmarks(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(2)
+
+	t.Run("Success GetBookmarks - Collection", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo}
+		collection := " later "
+		bookmarks := model.GetBookmarksResponse{Data: []model.BookmarkedPost{{Collection: "later"}}}
+
+		mockBookmarkRepo.On("GetBookmarks", ctx, userID, mock.MatchedBy(func(collection *string) bool {
+			return collection != nil && *collection == "later"
+		}), 20, 20).Return(bookmarks, nil)
+
+		resp, err := usecase.GetBookmarks(ctx, userID, &collection, 20, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bookmarks, resp)
+		mockBookmarkRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success GetBookmarks - All Collections", func(t *testing.T) {
+		mockBookmarkRepo := new(mocks.MockBookmarkRepository)
+		usecase := &bookmarkUsecase{bookmarkRepository: mockBookmarkRepo}
+
+		mockBookmarkRepo.On("GetBookmarks", ctx, userID, (*string)(nil), defaultPageSize, 0).Return(model.GetBookmarksResponse{}, nil)
+
+		_, err := usecase.GetBookmarks(ctx, userID, nil, 0, 0)
+
+		assert.NoError(t, err)
+		mockBookmarkRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+// GetComments pages through the comment threads of the post, a page holds
+// top level comments together with their first replies
+func (u *postUsecase) GetComments(ctx context.Context, postID, viewerID int64, sort model.CommentSort, pageSize int, cursor string, threaded bool) (comments model.GetCommentsResponse, err error) {
+	if sort == "" {
+		sort = model.CommentSortOldest
+	}
+	switch sort {
+	case model.CommentSortOldest, model.CommentSortNewest, model.CommentSortTop:
+	default:
+		return comments, fmt.Errorf("%w: unknown sort %q", model.ErrInvalidInput, sort)
+	}
+
+	_, err = u.getVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		return
+	}
+
+	page := model.PageQuery{Limit: normalizePageSize(pageSize)}
+	if cursor != "" {
+		decoded, decodeErr := utils.DecodeCursor(cursor)
+		if decodeErr != nil {
+			return comments, fmt.Errorf("%w: malformed cursor", model.ErrInvalidInput)
+		}
+		if model.CommentSort(decoded.Sort) != sort {
+			return comments, fmt.Errorf("%w: cursor was issued for another sort", model.ErrInvalidInput)
+		}
+		page.Cursor = &decoded
+	}
+
+	return u.getCommentThreads(ctx, postID, sort, page, threaded)
+}
+
+// getCommentThreads fetches a page of top level comments and threads their
+// first replies below them. Comments with more replies than embedded are
+// flagged with HasMoreReplies, the rest is paged through GetCommentReplies.
+func (u *postUsecase) getCommentThreads(ctx context.Context, postID int64, sort model.CommentSort, page model.PageQuery, threaded bool) (comments model.GetCommentsResponse, err error) {
+	comments, err = u.postRepository.GetComments(ctx, postID, sort, page)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comments from database")
+		return
+	}
+
+	comments.Pagination.NextCursor, comments.Pagination.PrevCursor = pageCursors(comments.Data, page, comments.Pagination.HasMore, func(comment model.CommentResponse, backward bool) model.Cursor {
+		return commentCursor(comment, sort, backward)
+	})
+
+	rootIDs := make([]int64, 0, len(comments.Data))
+	for _, comment := range comments.Data {
+		rootIDs = append(rootIDs, comment.ID)
+	}
+
+	replies, threadSizes, err := u.postRepository.GetCommentReplies(ctx, rootIDs, u.commentReplyLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment replies from database")
+		return
+	}
+
+	comments.Data = threadComments(append(comments.Data, replies...), threaded)
+	for i, comment := range comments.Data {
+		if size := threadSizes[comment.ID]; size > comment.ReplyCount {
+			comments.Data[i].ReplyCount = size
+			comments.Data[i].HasMoreReplies = true
+		}
+	}
+	return
+}
+
+// GetCommentReplies pages through every reply below the comment, at any depth,
+// in the order they were written. Replies are threaded within the page.
+func (u *postUsecase) GetCommentReplies(ctx context.Context, postID, commentID, viewerID int64, pageSize, pageIndex int, threaded bool) (replies model.GetCommentsResponse, err error) {
+	_, err = u.getVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		return
+	}
+
+	// deleted comments stay listed as placeholders, so their replies remain reachable
+	comment, err := u.postRepository.GetComment(ctx, commentID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment from database")
+		return
+	}
+	if comment.ID == 0 || comment.PostID != postID || comment.Status != model.CommentStatusApproved {
+		return replies, model.ErrCommentNotFound
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	replies, err = u.postRepository.GetCommentThread(ctx, commentID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment thread from database")
+		return
+	}
+
+	replies.Data = threadComments(replies.Data, threaded)
+	return
+}
+
+// commentCursor returns the cursor pointing at the top level comment for the
+// given sort, ReplyCount must still hold the direct replies of the comment
+func commentCursor(comment model.CommentResponse, sort model.CommentSort, backward bool) model.Cursor {
+	cursor := model.Cursor{Sort: string(sort), ID: comment.ID, Backward: backward}
+	if sort == model.CommentSortTop {
+		cursor.Count = int64(comment.ReplyCount)
+	} else {
+		cursor.Time = comment.CreatedAt
+	}
+	return cursor
+}
+
+// CreateComment adds a comment to the post and returns its status. Comments
+// flagged by the content filters, and comments on posts requiring approval not
+// written by the post author, stay pending.
+func (u *postUsecase) CreateComment(ctx context.Context, postID, userID int64, request model.CreateCommentRequest) (status model.CommentStatus, err error) {
+	post, err := u.getVisiblePost(ctx, postID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if post.CommentMode == model.CommentModeClosed {
+		return "", fmt.Errorf("%w: comments are closed on this post", model.ErrForbidden)
+	}
+
+	if request.ParentCommentID != nil {
+		err = u.validateCommentParent(ctx, postID, *request.ParentCommentID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	action, err := u.checkContent(ctx, userID, model.ContentKindComment, 0, request.CommentContent)
+	if err != nil {
+		return "", err
+	}
+
+	status = model.CommentStatusApproved
+	if action == filter.ActionModerate || (post.CommentMode == model.CommentModeApproval && post.UserID != userID) {
+		status = model.CommentStatusPending
+	}
+
+	now := time.Now()
+	comment := model.Comment{
+		PostID:          postID,
+		UserID:          userID,
+		ParentCommentID: request.ParentCommentID,
+		CommentContent:  request.CommentContent,
+		Status:          status,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		CreatedBy:       strconv.FormatInt(userID, 10),
+		UpdatedBy:       strconv.FormatInt(userID, 10),
+	}
+
+	_, err = u.postRepository.CreateComment(ctx, comment)
+	if err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// UpdateComment edits the content of a comment, only its author may edit it and
+// only once the content filters accept the new content. An edit the filters
+// hold for moderation sends the comment back to pending.
+func (u *postUsecase) UpdateComment(ctx context.Context, postID, commentID, userID int64, request model.UpdateCommentRequest) (status model.CommentStatus, err error) {
+	if strings.TrimSpace(request.CommentContent) == "" {
+		return "", fmt.Errorf("%w: comment content is required", model.ErrInvalidInput)
+	}
+
+	_, err = u.getVisiblePost(ctx, postID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	comment, err := u.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return "", err
+	}
+
+	if comment.UserID != userID {
+		return "", model.ErrForbidden
+	}
+
+	action, err := u.checkContent(ctx, userID, model.ContentKindComment, comment.ID, request.CommentContent)
+	if err != nil {
+		return "", err
+	}
+
+	if action == filter.ActionModerate {
+		comment.Status = model.CommentStatusPending
+	}
+
+	now := time.Now()
+	comment.CommentContent = request.CommentContent
+	comment.EditedAt = &now
+	comment.UpdatedAt = now
+	comment.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.UpdateComment(ctx, comment)
+	if err != nil {
+		return "", err
+	}
+	return comment.Status, nil
+}
+
+// DeleteComment moves a comment to the trash, either the comment author or the
+// post author may delete it. Replies stay visible under a placeholder.
+func (u *postUsecase) DeleteComment(ctx context.Context, postID, commentID, userID int64) (err error) {
+	post, err := u.getVisiblePost(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	comment, err := u.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID && post.UserID != userID {
+		return model.ErrForbidden
+	}
+
+	now := time.Now()
+	comment.DeletedAt = &now
+	comment.DeletedBy = &userID
+	comment.UpdatedAt = now
+	comment.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.DeleteComment(ctx, comment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// getPostComment returns a comment that is not in the trash and belongs to the post
+func (u *postUsecase) getPostComment(ctx context.Context, postID, commentID int64) (comment model.Comment, err error) {
+	comment, err = u.postRepository.GetComment(ctx, commentID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment from database")
+		return
+	}
+
+	if comment.ID == 0 || comment.DeletedAt != nil || comment.PostID != postID {
+		return comment, model.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// validateCommentParent checks that the parent comment belongs to the post and
+// that a reply to it stays within the maximum thread depth
+func (u *postUsecase) validateCommentParent(ctx context.Context, postID, parentID int64) (err error) {
+	parent, err := u.postRepository.GetComment(ctx, parentID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment from database")
+		return err
+	}
+
+	if parent.ID == 0 || parent.DeletedAt != nil || parent.Status != model.CommentStatusApproved {
+		return model.ErrCommentNotFound
+	}
+	if parent.PostID != postID {
+		return fmt.Errorf("%w: parent comment belongs to another post", model.ErrInvalidInput)
+	}
+
+	// walk up the thread, never further than the maximum depth
+	depth := 0
+	for ancestor := parent; ancestor.ParentCommentID != nil && depth < u.maxCommentDepth; depth++ {
+		ancestor, err = u.postRepository.GetComment(ctx, *ancestor.ParentCommentID)
+		if err != nil {
+			log.Error().Err(err).Msg("error get comment from database")
+			return err
+		}
+		if ancestor.ID == 0 {
+			break
+		}
+	}
+
+	if depth+1 > u.maxCommentDepth {
+		return fmt.Errorf("%w: replies cannot be nested deeper than %d levels", model.ErrInvalidInput, u.maxCommentDepth)
+	}
+	return nil
+}
+
+// threadComments arranges comments into threads, top level comments keep their
+// order and replies are expected in creation order. As a tree only top level
+// comments are returned with their replies nested, as a flat list every comment
+// is returned in thread order. Replies whose parent is missing are treated as
+// top level comments. ReplyCount is recomputed from the given comments.
+func threadComments(comments []model.CommentResponse, tree bool) []model.CommentResponse {
+	exists := make(map[int64]bool, len(comments))
+	for _, comment := range comments {
+		exists[comment.ID] = true
+	}
+
+	roots := []model.CommentResponse{}
+	replies := make(map[int64][]model.CommentResponse)
+	for _, comment := range comments {
+		if comment.ParentCommentID != nil && exists[*comment.ParentCommentID] {
+			replies[*comment.ParentCommentID] = append(replies[*comment.ParentCommentID], comment)
+			continue
+		}
+		roots = append(roots, comment)
+	}
+
+	threaded := make([]model.CommentResponse, 0, len(comments))
+	var walk func(comment model.CommentResponse, depth int) model.CommentResponse
+	walk = func(comment model.CommentResponse, depth int) model.CommentResponse {
+		comment.Depth = depth
+		comment.ReplyCount = 0
+
+		index := len(threaded)
+		if !tree {
+			threaded = append(threaded, comment)
+		}
+
+		for _, reply := range replies[comment.ID] {
+			reply = walk(reply, depth+1)
+			comment.ReplyCount += reply.ReplyCount + 1
+			if tree {
+				comment.Replies = append(comment.Replies, reply)
+			}
+		}
+
+		if !tree {
+			threaded[index].ReplyCount = comment.ReplyCount
+		}
+		return comment
+	}
+
+	for _, root := range roots {
+		root = walk(root, 0)
+		if tree {
+			threaded = append(threaded, root)
+		}
+	}
+	return threaded
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func (u *postUsecase) UpdateCommentSettings(ctx context.Context, postID, userID int64, req model.UpdateCommentSettingsRequest) (err error) {
+	switch req.CommentMode {
+	case model.CommentModeOpen, model.CommentModeClosed, model.CommentModeApproval:
+	default:
+		return fmt.Errorf("%w: unknown comment mode %q", model.ErrInvalidInput, req.CommentMode)
+	}
+
+	post, err := u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return err
+	}
+
+	post.CommentMode = req.CommentMode
+	post.UpdatedAt = time.Now()
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.UpdatePostCommentMode(ctx, post)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingComments lists the comments awaiting approval on a post owned by the user
+func (u *postUsecase) GetPendingComments(ctx context.Context, postID, userID int64) (comments []model.CommentResponse, err error) {
+	_, err = u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return
+	}
+
+	comments, err = u.postRepository.GetPendingComments(ctx, postID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get pending comments from database")
+		return
+	}
+	return
+}
+
+func (u *postUsecase) ApproveComment(ctx context.Context, postID, commentID, userID int64) (err error) {
+	return u.moderateComment(ctx, postID, commentID, userID, model.CommentStatusApproved)
+}
+
+func (u *postUsecase) RejectComment(ctx context.Context, postID, commentID, userID int64) (err error) {
+	return u.moderateComment(ctx, postID, commentID, userID, model.CommentStatusRejected)
+}
+
+// moderateComment records the decision of the post author on a pending comment.
+// The content filters only learn from it when the post author is a moderator,
+// any user could otherwise teach the filters what spam looks like.
+func (u *postUsecase) moderateComment(ctx context.Context, postID, commentID, userID int64, status model.CommentStatus) (err error) {
+	_, err = u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return err
+	}
+
+	comment, err := u.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.Status != model.CommentStatusPending {
+		return fmt.Errorf("%w: comment is not pending", model.ErrInvalidInput)
+	}
+
+	comment.Status = status
+	comment.UpdatedAt = time.Now()
+	comment.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.UpdateCommentStatus(ctx, comment)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(u.moderatorIDs, userID) {
+		u.trainContentFilter(ctx, comment.CommentContent, status == model.CommentStatusRejected)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestUpdateCommentSettings(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(2)
+	post := model.Post{ID: postID, UserID: userID, CommentMode: model.CommentModeOpen}
+
+	t.Run("Success UpdateCommentSettings", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("UpdatePostCommentMode", ctx, mock.MatchedBy(func(p model.Post) bool {
+			return p.CommentMode == model.CommentModeClosed && p.UpdatedBy == "2"
+		})).Return(nil)
+
+		err := usecase.UpdateCommentSettings(ctx, postID, userID, model.UpdateCommentSettingsRequest{CommentMode: model.CommentModeClosed})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateCommentSettings - Unknown Mode", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		err := usecase.UpdateCommentSettings(ctx, postID, userID, model.UpdateCommentSettingsRequest{CommentMode: "members"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateCommentSettings - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+
+		err := usecase.UpdateCommentSettings(ctx, postID, 3, model.UpdateCommentSettingsRequest{CommentMode: model.CommentModeClosed})
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetPendingComments(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(2)
+	post := model.Post{ID: postID, UserID: userID}
+
+	t.Run("Success GetPendingComments", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		pending := []model.CommentResponse{{ID: 10, UserID: 3, CommentContent: "Waiting"}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetPendingComments", ctx, postID).Return(pending, nil)
+
+		comments, err := usecase.GetPendingComments(ctx, postID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, pending, comments)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPendingComments - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+
+		_, err := usecase.GetPendingComments(ctx, postID, 3)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestModerateComment(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(10)
+	userID := int64(2)
+	post := model.Post{ID: postID, UserID: userID}
+	pending := model.Comment{ID: commentID, PostID: postID, UserID: 3, Status: model.CommentStatusPending}
+
+	t.Run("Success ApproveComment", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(pending, nil)
+		mockRepo.On("UpdateCommentStatus", ctx, mock.MatchedBy(func(c model.Comment) bool {
+			return c.Status == model.CommentStatusApproved
+		})).Return(nil)
+
+		err := usecase.ApproveComment(ctx, postID, commentID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success RejectComment", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(pending, nil)
+		mockRepo.On("UpdateCommentStatus", ctx, mock.MatchedBy(func(c model.Comment) bool {
+			return c.Status == model.CommentStatusRejected
+		})).Return(nil)
+
+		err := usecase.RejectComment(ctx, postID, commentID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail ApproveComment - Not Pending", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		approved := pending
+		approved.Status = model.CommentStatusApproved
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(approved, nil)
+
+		err := usecase.ApproveComment(ctx, postID, commentID, userID)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RejectComment - Not Post Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+
+		err := usecase.RejectComment(ctx, postID, commentID, 3)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,524 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestCreateComment(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(2)
+	req := model.CreateCommentRequest{
+		CommentContent: "This is a test comment",
+	}
+	post := model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}
+
+	t.Run("Success CreateComment", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("CreateComment", ctx, mock.AnythingOfType("model.Comment")).Return(int64(1), nil)
+
+		status, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.CommentStatusApproved, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success CreateComment - Requires Approval", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		moderated := post
+		moderated.CommentMode = model.CommentModeApproval
+
+		mockRepo.On("GetPost", ctx, postID).Return(moderated, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("CreateComment", ctx, mock.MatchedBy(func(comment model.Comment) bool {
+			return comment.Status == model.CommentStatusPending
+		})).Return(int64(1), nil)
+
+		status, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.CommentStatusPending, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success CreateComment - Post Author Skips Approval", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		moderated := post
+		moderated.CommentMode = model.CommentModeApproval
+
+		mockRepo.On("GetPost", ctx, postID).Return(moderated, nil)
+		mockRepo.On("CreateComment", ctx, mock.AnythingOfType("model.Comment")).Return(int64(1), nil)
+
+		status, err := usecase.CreateComment(ctx, postID, post.UserID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.CommentStatusApproved, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Comments Closed", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		closed := post
+		closed.CommentMode = model.CommentModeClosed
+
+		mockRepo.On("GetPost", ctx, postID).Return(closed, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success CreateComment - Reply", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, maxCommentDepth: 2}
+		replyReq := req
+		replyReq.ParentCommentID = int64Ptr(10)
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, int64(10)).Return(model.Comment{ID: 10, PostID: postID, Status: model.CommentStatusApproved, ParentCommentID: int64Ptr(9)}, nil)
+		mockRepo.On("GetComment", ctx, int64(9)).Return(model.Comment{ID: 9, PostID: postID}, nil)
+		mockRepo.On("CreateComment", ctx, mock.MatchedBy(func(comment model.Comment) bool {
+			return comment.ParentCommentID != nil && *comment.ParentCommentID == 10
+		})).Return(int64(11), nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, replyReq)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Blocked By Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(true, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertNotCalled(t, "CreateComment")
+	})
+
+	t.Run("Fail CreateComment - Post Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{}, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Parent On Another Post", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, maxCommentDepth: 2}
+		replyReq := req
+		replyReq.ParentCommentID = int64Ptr(10)
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, int64(10)).Return(model.Comment{ID: 10, PostID: 99, Status: model.CommentStatusApproved}, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, replyReq)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Parent Trashed", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, maxCommentDepth: 2}
+		replyReq := req
+		replyReq.ParentCommentID = int64Ptr(10)
+		now := time.Now()
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, int64(10)).Return(model.Comment{ID: 10, PostID: postID, DeletedAt: &now}, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, replyReq)
+
+		assert.ErrorIs(t, err, model.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Too Deep", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, maxCommentDepth: 1}
+		replyReq := req
+		replyReq.ParentCommentID = int64Ptr(10)
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, int64(10)).Return(model.Comment{ID: 10, PostID: postID, Status: model.CommentStatusApproved, ParentCommentID: int64Ptr(9)}, nil)
+		mockRepo.On("GetComment", ctx, int64(9)).Return(model.Comment{ID: 9, PostID: postID}, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, replyReq)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("CreateComment", ctx, mock.AnythingOfType("model.Comment")).Return(int64(0), assert.AnError)
+
+		_, err := usecase.CreateComment(ctx, postID, userID, req)
+
+		assert.Error(t, err)
+		assert.Equal(t, assert.AnError, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetComments(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	viewerID := int64(2)
+	post := model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}
+	now := time.Now()
+
+	roots := []model.CommentResponse{
+		{ID: 1, UserID: 2, CommentContent: "First", CreatedAt: now, ReplyCount: 1},
+		{ID: 5, UserID: 3, CommentContent: "Second", CreatedAt: now},
+	}
+	replies := []model.CommentResponse{
+		{ID: 6, UserID: 3, CommentContent: "Reply", ParentCommentID: int64Ptr(1), CreatedAt: now},
+	}
+
+	t.Run("Success GetComments", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, commentReplyLimit: 1}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, viewerID).Return(false, nil)
+		mockRepo.On("GetComments", ctx, postID, model.CommentSortTop, model.PageQuery{Limit: 2}).Return(model.GetCommentsResponse{
+			Data:       roots,
+			Pagination: model.Pagination{Limit: 2, Total: 3, HasMore: true},
+		}, nil)
+		mockRepo.On("GetCommentReplies", ctx, []int64{1, 5}, 1).Return(replies, map[int64]int{1: 4}, nil)
+
+		resp, err := usecase.GetComments(ctx, postID, viewerID, model.CommentSortTop, 2, "", true)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, 4, resp.Data[0].ReplyCount)
+		assert.True(t, resp.Data[0].HasMoreReplies)
+		assert.Len(t, resp.Data[0].Replies, 1)
+		assert.False(t, resp.Data[1].HasMoreReplies)
+		assert.Empty(t, resp.Pagination.PrevCursor)
+
+		next, err := utils.DecodeCursor(resp.Pagination.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Cursor{Sort: "top", ID: 5}, next)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetComments - Unknown Sort", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		_, err := usecase.GetComments(ctx, postID, viewerID, "random", 10, "", false)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetComments - Cursor For Another Sort", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		cursor := utils.EncodeCursor(model.Cursor{Sort: "newest", Time: now, ID: 5})
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, viewerID).Return(false, nil)
+
+		_, err := usecase.GetComments(ctx, postID, viewerID, model.CommentSortOldest, 10, cursor, false)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetCommentReplies(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(1)
+	viewerID := int64(2)
+	post := model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}
+	now := time.Now()
+
+	replies := []model.CommentResponse{
+		{ID: 7, UserID: 3, CommentContent: "Reply", ParentCommentID: int64Ptr(commentID), CreatedAt: now},
+		{ID: 8, UserID: 2, CommentContent: "Nested reply", ParentCommentID: int64Ptr(7), CreatedAt: now},
+	}
+
+	t.Run("Success GetCommentReplies", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, viewerID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(model.Comment{ID: commentID, PostID: postID, Status: model.CommentStatusApproved, DeletedAt: &now}, nil)
+		mockRepo.On("GetCommentThread", ctx, commentID, 10, 10).Return(model.GetCommentsResponse{
+			Data:       replies,
+			Pagination: model.Pagination{Limit: 10, Offset: 10, Total: 12},
+		}, nil)
+
+		resp, err := usecase.GetCommentReplies(ctx, postID, commentID, viewerID, 10, 2, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, 1, resp.Data[0].ReplyCount)
+		assert.Equal(t, 1, resp.Data[0].Replies[0].Depth)
+		assert.Equal(t, 12, resp.Pagination.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetCommentReplies - Comment On Another Post", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, viewerID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(model.Comment{ID: commentID, PostID: 9, Status: model.CommentStatusApproved}, nil)
+
+		_, err := usecase.GetCommentReplies(ctx, postID, commentID, viewerID, 10, 1, false)
+
+		assert.ErrorIs(t, err, model.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateComment(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(10)
+	userID := int64(2)
+	req := model.UpdateCommentRequest{CommentContent: "Edited comment"}
+	post := model.Post{ID: postID, UserID: 1, Status: model.PostStatusPublished}
+	comment := model.Comment{ID: commentID, PostID: postID, UserID: userID, CommentContent: "Original comment"}
+
+	t.Run("Success UpdateComment", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+		mockRepo.On("UpdateComment", ctx, mock.MatchedBy(func(c model.Comment) bool {
+			return c.CommentContent == req.CommentContent && c.EditedAt != nil && c.UpdatedBy == "2"
+		})).Return(nil)
+
+		_, err := usecase.UpdateComment(ctx, postID, commentID, userID, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateComment - Not Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, int64(3)).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+
+		_, err := usecase.UpdateComment(ctx, postID, commentID, 3, req)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateComment - Blocked By Post Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(true, nil)
+
+		_, err := usecase.UpdateComment(ctx, postID, commentID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertNotCalled(t, "UpdateComment")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateComment - Comment On Another Post", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, int64(99)).Return(model.Post{ID: 99, UserID: 1, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+
+		_, err := usecase.UpdateComment(ctx, 99, commentID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateComment - Empty Content", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		_, err := usecase.UpdateComment(ctx, postID, commentID, userID, model.UpdateCommentRequest{CommentContent: "  "})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(10)
+	userID := int64(2)
+	post := model.Post{ID: postID, UserID: 5, Status: model.PostStatusPublished}
+	comment := model.Comment{ID: commentID, PostID: postID, UserID: userID}
+
+	t.Run("Success DeleteComment - Comment Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+		mockRepo.On("DeleteComment", ctx, mock.MatchedBy(func(c model.Comment) bool {
+			return c.ID == commentID && c.DeletedAt != nil && *c.DeletedBy == userID
+		})).Return(nil)
+
+		err := usecase.DeleteComment(ctx, postID, commentID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success DeleteComment - Post Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+		mockRepo.On("DeleteComment", ctx, mock.MatchedBy(func(c model.Comment) bool {
+			return c.ID == commentID && *c.DeletedBy == 5
+		})).Return(nil)
+
+		err := usecase.DeleteComment(ctx, postID, commentID, 5)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail DeleteComment - Forbidden", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, int64(7)).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+
+		err := usecase.DeleteComment(ctx, postID, commentID, 7)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail DeleteComment - Blocked By Post Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(true, nil)
+
+		err := usecase.DeleteComment(ctx, postID, commentID, userID)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertNotCalled(t, "DeleteComment")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail DeleteComment - Already Deleted", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		deleted := comment
+		deletedAt := time.Now()
+		deleted.DeletedAt = &deletedAt
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, userID).Return(false, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(deleted, nil)
+
+		err := usecase.DeleteComment(ctx, postID, commentID, userID)
+
+		assert.ErrorIs(t, err, model.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestThreadComments(t *testing.T) {
+	comments := []model.CommentResponse{
+		{ID: 1},
+		{ID: 2},
+		{ID: 3, ParentCommentID: int64Ptr(1)},
+		{ID: 4, ParentCommentID: int64Ptr(3)},
+		{ID: 5, ParentCommentID: int64Ptr(1)},
+		{ID: 6, ParentCommentID: int64Ptr(42)},
+	}
+
+	t.Run("Flat", func(t *testing.T) {
+		flat := threadComments(comments, false)
+
+		var ids, depths, replyCounts []int64
+		for _, comment := range flat {
+			ids = append(ids, comment.ID)
+			depths = append(depths, int64(comment.Depth))
+			replyCounts = append(replyCounts, int64(comment.ReplyCount))
+			assert.Nil(t, comment.Replies)
+		}
+		assert.Equal(t, []int64{1, 3, 4, 5, 2, 6}, ids)
+		assert.Equal(t, []int64{0, 1, 2, 1, 0, 0}, depths)
+		assert.Equal(t, []int64{3, 1, 0, 0, 0, 0}, replyCounts)
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		tree := threadComments(comments, true)
+
+		assert.Len(t, tree, 3)
+		assert.Equal(t, int64(1), tree[0].ID)
+		assert.Equal(t, 3, tree[0].ReplyCount)
+		assert.Len(t, tree[0].Replies, 2)
+		assert.Equal(t, int64(4), tree[0].Replies[0].Replies[0].ID)
+		assert.Equal(t, 2, tree[0].Replies[0].Replies[0].Depth)
+		assert.Equal(t, int64(6), tree[2].ID)
+	})
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+)
+
+// checkContent runs the content policy on text submitted by the user and
+// returns ErrContentRejected when the filters reject it. The id is the post or
+// comment being edited, zero for new content.
+func (u *postUsecase) checkContent(ctx context.Context, userID int64, kind model.ContentKind, id int64, text string) (action filter.Action, err error) {
+	action, verdict, err := u.contentPolicy.Evaluate(ctx, filter.Content{ID: id, UserID: userID, Kind: kind, Text: text})
+	if err != nil {
+		log.Error().Err(err).Msg("error check content")
+		return action, err
+	}
+
+	if action != filter.ActionAllow {
+		log.Info().Int64("user_id", userID).Str("kind", string(kind)).Str("action", string(action)).
+			Float64("score", verdict.Score).Strs("reasons", verdict.Reasons).Msg("content filtered")
+	}
+	if action == filter.ActionReject {
+		return action, fmt.Errorf("%w: %s", model.ErrContentRejected, strings.Join(verdict.Reasons, ", "))
+	}
+	return action, nil
+}
+
+// trainContentFilter teaches the filters the decision of a moderator. The decision
+// is already stored, so failing to learn from it is only logged.
+func (u *postUsecase) trainContentFilter(ctx context.Context, text string, spam bool) {
+	if u.contentTrainer == nil {
+		return
+	}
+
+	err := u.contentTrainer.Train(ctx, text, spam)
+	if err != nil {
+		log.Error().Err(err).Msg("error train content filter")
+	}
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+)
+
+type scoreFilter float64
+
+func (f scoreFilter) Check(ctx context.Context, content filter.Content) (filter.Verdict, error) {
+	return filter.Verdict{Score: float64(f), Reasons: []string{"test filter"}}, nil
+}
+
+type trainedSample struct {
+	text string
+	spam bool
+}
+
+type recordingTrainer struct {
+	samples []trainedSample
+}
+
+func (t *recordingTrainer) Train(ctx context.Context, text string, spam bool) error {
+	t.samples = append(t.samples, trainedSample{text: text, spam: spam})
+	return nil
+}
+
+func policyScoring(score float64) filter.Policy {
+	return filter.Policy{Filter: scoreFilter(score), ModerateScore: 0.5, RejectScore: 0.9}
+}
+
+func TestCreatePostContentFilter(t *testing.T) {
+	ctx := context.Background()
+	req := model.CreatePostRequest{PostTitle: "Cheap pills", PostContent: "Buy now"}
+
+	t.Run("Fail CreatePost - Rejected", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(1)}
+
+		_, err := usecase.CreatePost(ctx, 1, req)
+
+		assert.ErrorIs(t, err, model.ErrContentRejected)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success CreatePost - Moderated", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.6)}
+
+		mockRepo.On("CreatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusPending && post.PublishAt == nil
+		}), mock.AnythingOfType("model.PostRevision")).Return(int64(1), nil)
+
+		status, err := usecase.CreatePost(ctx, 1, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.PostStatusPending, status)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdatePostContentFilter(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(2)
+	publishedAt := time.Now()
+	post := model.Post{ID: postID, UserID: userID, Status: model.PostStatusPublished, PublishAt: &publishedAt}
+	req := model.UpdatePostRequest{PostTitle: "Cheap pills", PostContent: "Buy now"}
+
+	t.Run("Success UpdatePost - Moderated", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.6)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("CountPostRevisions", ctx, postID).Return(1, nil)
+		mockRepo.On("UpdatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusPending && post.PublishAt == nil
+		}), mock.AnythingOfType("[]model.PostRevision")).Return(nil)
+
+		status, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.PostStatusPending, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePost - Rejected", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(1)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrContentRejected)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdatePost")
+	})
+}
+
+func TestCreateCommentContentFilter(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	post := model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished, CommentMode: model.CommentModeOpen}
+	req := model.CreateCommentRequest{CommentContent: "Buy cheap pills"}
+
+	t.Run("Success CreateComment - Moderated", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.6)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, int64(2)).Return(false, nil)
+		mockRepo.On("CreateComment", ctx, mock.MatchedBy(func(comment model.Comment) bool {
+			return comment.Status == model.CommentStatusPending
+		})).Return(int64(1), nil)
+
+		status, err := usecase.CreateComment(ctx, postID, 2, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.CommentStatusPending, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreateComment - Rejected", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.95)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("IsBlocked", ctx, post.UserID, int64(2)).Return(false, nil)
+
+		_, err := usecase.CreateComment(ctx, postID, 2, req)
+
+		assert.ErrorIs(t, err, model.ErrContentRejected)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateCommentContentFilter(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(10)
+	userID := int64(2)
+	post := model.Post{ID: postID, UserID: userID, Status: model.PostStatusPublished}
+	comment := model.Comment{ID: commentID, PostID: postID, UserID: userID, CommentContent: "Original", Status: model.CommentStatusApproved}
+	req := model.UpdateCommentRequest{CommentContent: "Buy cheap pills"}
+
+	t.Run("Success UpdateComment - Moderated", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.6)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+		mockRepo.On("UpdateComment", ctx, mock.MatchedBy(func(comment model.Comment) bool {
+			return comment.Status == model.CommentStatusPending
+		})).Return(nil)
+
+		status, err := usecase.UpdateComment(ctx, postID, commentID, userID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.CommentStatusPending, status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateComment - Rejected", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, contentPolicy: policyScoring(0.95)}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(comment, nil)
+
+		_, err := usecase.UpdateComment(ctx, postID, commentID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrContentRejected)
+		mockRepo.AssertNotCalled(t, "UpdateComment")
+	})
+}
+
+func TestModerateCommentTrainsFilter(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	commentID := int64(10)
+	post := model.Post{ID: postID, UserID: 2}
+	pending := model.Comment{ID: commentID, PostID: postID, UserID: 3, CommentContent: "Buy cheap pills", Status: model.CommentStatusPending}
+
+	t.Run("Moderator", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		trainer := &recordingTrainer{}
+		usecase := &postUsecase{postRepository: mockRepo, contentTrainer: trainer, moderatorIDs: []int64{2}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(pending, nil)
+		mockRepo.On("UpdateCommentStatus", ctx, mock.AnythingOfType("model.Comment")).Return(nil)
+
+		assert.NoError(t, usecase.RejectComment(ctx, postID, commentID, 2))
+		assert.NoError(t, usecase.ApproveComment(ctx, postID, commentID, 2))
+
+		assert.Equal(t, []trainedSample{
+			{text: "Buy cheap pills", spam: true},
+			{text: "Buy cheap pills", spam: false},
+		}, trainer.samples)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Moderator", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		trainer := &recordingTrainer{}
+		usecase := &postUsecase{postRepository: mockRepo, contentTrainer: trainer}
+
+		mockRepo.On("GetPost", ctx, postID).Return(post, nil)
+		mockRepo.On("GetComment", ctx, commentID).Return(pending, nil)
+		mockRepo.On("UpdateCommentStatus", ctx, mock.AnythingOfType("model.Comment")).Return(nil)
+
+		assert.NoError(t, usecase.RejectComment(ctx, postID, commentID, 2))
+
+		assert.Empty(t, trainer.samples)
+		mockRepo.AssertExpectations(t)
+	})
+}
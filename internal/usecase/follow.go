@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	repository "github.com/suhriar/blog-mono-api/internal/repository/mysql"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func (u *followUsecase) FollowUser(ctx context.Context, followeeID, userID int64) (err error) {
+	if followeeID == userID {
+		return fmt.Errorf("%w: you cannot follow yourself", model.ErrInvalidInput)
+	}
+
+	if err = checkUserExists(ctx, u.userRepository, followeeID); err != nil {
+		return
+	}
+
+	// a block in either direction ends the follows and keeps them from coming back
+	blocked, err := u.followRepository.HasBlock(ctx, userID, followeeID)
+	if err != nil {
+		log.Error().Err(err).Msg("error check block from database")
+		return
+	}
+	if blocked {
+		return fmt.Errorf("%w: you cannot follow this user", model.ErrForbidden)
+	}
+
+	err = u.followRepository.CreateFollow(ctx, model.Follow{
+		FollowerID: userID,
+		FolloweeID: followeeID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error create follow to database")
+		return
+	}
+	return
+}
+
+func (u *followUsecase) UnfollowUser(ctx context.Context, followeeID, userID int64) (err error) {
+	deleted, err := u.followRepository.DeleteFollow(ctx, userID, followeeID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete follow from database")
+		return
+	}
+	if !deleted {
+		return model.ErrFollowNotFound
+	}
+	return
+}
+
+// GetFollowers pages through the users following the user
+func (u *followUsecase) GetFollowers(ctx context.Context, userID int64, pageSize, pageIndex int) (followers model.GetFollowsResponse, err error) {
+	if err = checkUserExists(ctx, u.userRepository, userID); err != nil {
+		return
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	followers, err = u.followRepository.GetFollowers(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get followers from database")
+		return
+	}
+	return
+}
+
+// GetFollowing pages through the users followed by the user
+func (u *followUsecase) GetFollowing(ctx context.Context, userID int64, pageSize, pageIndex int) (following model.GetFollowsResponse, err error) {
+	if err = checkUserExists(ctx, u.userRepository, userID); err != nil {
+		return
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	following, err = u.followRepository.GetFollowing(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get following from database")
+		return
+	}
+	return
+}
+
+func (u *followUsecase) GetFollowCounts(ctx context.Context, userID, viewerID int64) (counts model.FollowCounts, err error) {
+	if err = checkUserExists(ctx, u.userRepository, userID); err != nil {
+		return
+	}
+
+	counts, err = u.followRepository.GetFollowCounts(ctx, userID, viewerID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get follow counts from database")
+		return
+	}
+	return
+}
+
+// checkUserExists returns ErrUserNotFound when there is no user with the id
+func checkUserExists(ctx context.Context, userRepository repository.UserRepository, userID int64) error {
+	user, err := userRepository.GetUser(ctx, "", "", userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if user.ID == 0 {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestFollowUser(t *testing.T) {
+	ctx := context.Background()
+	followeeID := int64(2)
+	userID := int64(1)
+
+	t.Run("Success FollowUser", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", followeeID).Return(model.User{ID: followeeID}, nil)
+		mockFollowRepo.On("HasBlock", ctx, userID, followeeID).Return(false, nil)
+		mockFollowRepo.On("CreateFollow", ctx, mock.MatchedBy(func(follow model.Follow) bool {
+			return follow.FollowerID == userID && follow.FolloweeID == followeeID && !follow.CreatedAt.IsZero()
+		})).Return(nil)
+
+		err := usecase.FollowUser(ctx, followeeID, userID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockFollowRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail FollowUser - Self", func(t *testing.T) {
+		usecase := &followUsecase{}
+
+		err := usecase.FollowUser(ctx, userID, userID)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+
+	t.Run("Fail FollowUser - User Not Found", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", followeeID).Return(model.User{}, nil)
+
+		err := usecase.FollowUser(ctx, followeeID, userID)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+		mockFollowRepo.AssertNotCalled(t, "CreateFollow")
+	})
+
+	t.Run("Fail FollowUser - Blocked", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", followeeID).Return(model.User{ID: followeeID}, nil)
+		mockFollowRepo.On("HasBlock", ctx, userID, followeeID).Return(true, nil)
+
+		err := usecase.FollowUser(ctx, followeeID, userID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockFollowRepo.AssertNotCalled(t, "CreateFollow")
+	})
+}
+
+func TestUnfollowUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success UnfollowUser", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo}
+
+		mockFollowRepo.On("DeleteFollow", ctx, int64(1), int64(2)).Return(true, nil)
+
+		err := usecase.UnfollowUser(ctx, 2, 1)
+
+		assert.NoError(t, err)
+		mockFollowRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UnfollowUser - Not Following", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo}
+
+		mockFollowRepo.On("DeleteFollow", ctx, int64(1), int64(2)).Return(false, nil)
+
+		err := usecase.UnfollowUser(ctx, 2, 1)
+
+		assert.ErrorIs(t, err, model.ErrFollowNotFound)
+	})
+}
+
+func TestGetFollowers(t *testing.T) {
+	ctx := context.Background()
+	mockFollowRepo := new(mocks.MockFollowRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	usecase := &followUsecase{followRepository: mockFollowRepo, userRepository: mockUserRepo}
+
+	expected := model.GetFollowsResponse{Data: []model.FollowUser{{UserID: 3, Username: "alice"}}}
+	mockUserRepo.On("GetUser", ctx, "", "", int64(2)).Return(model.User{ID: 2}, nil)
+	mockFollowRepo.On("GetFollowers", ctx, int64(2), 20, 20).Return(expected, nil)
+
+	followers, err := usecase.GetFollowers(ctx, 2, 20, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, followers)
+	mockFollowRepo.AssertExpectations(t)
+}
+
+func TestGetFollowCounts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Fail GetFollowCounts - User Not Found", func(t *testing.T) {
+		mockFollowRepo := new(mocks.MockFollowRepository)
+		mockUserRepo := new(mocks.MockUserRepository)
+		usecase := &followUsecase{followRepository: mockFollowRepo, userRepository: mockUserRepo}
+
+		mockUserRepo.On("GetUser", ctx, "", "", int64(2)).Return(model.User{}, nil)
+
+		_, err := usecase.GetFollowCounts(ctx, 2, 1)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+		mockFollowRepo.AssertNotCalled(t, "GetFollowCounts")
+	})
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// GetPostLikes pages through the users who liked a post the viewer may see
+func (u *postUsecase) GetPostLikes(ctx context.Context, postID, viewerID int64, pageSize, pageIndex int) (likes model.GetPostLikesResponse, err error) {
+	if _, err = u.getVisiblePost(ctx, postID, viewerID); err != nil {
+		return
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	likes, err = u.postRepository.GetPostLikes(ctx, postID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post likes from database")
+		return
+	}
+	return
+}
+
+// GetLikedPosts pages through the posts liked by the user, leaving out the
+// posts the viewer may not see
+func (u *postUsecase) GetLikedPosts(ctx context.Context, userID, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	posts, err = u.postRepository.GetLikedPosts(ctx, userID, viewerID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get liked posts from database")
+		return
+	}
+	return
+}
+
+// pageOffset turns a page size and a 1-based page index into a limit and offset,
+// pages before the first one select the first page
+func pageOffset(pageSize, pageIndex int) (limit, offset int) {
+	limit = normalizePageSize(pageSize)
+	if pageIndex > 1 {
+		offset = limit * (pageIndex - 1)
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetPostLikes(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	viewerID := int64(2)
+
+	t.Run("Success GetPostLikes", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		likes := model.GetPostLikesResponse{Data: []model.PostLike{{UserID: 3, Username: "user3"}}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 4, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(4), viewerID).Return(false, nil)
+		mockRepo.On("GetPostLikes", ctx, postID, 5, 10).Return(likes, nil)
+
+		resp, err := usecase.GetPostLikes(ctx, postID, viewerID, 5, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, likes, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostLikes - Post Not Visible", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 4, Status: model.PostStatusDraft}, nil)
+
+		_, err := usecase.GetPostLikes(ctx, postID, viewerID, 5, 1)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostLikes - Blocked By Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 4, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(4), viewerID).Return(true, nil)
+
+		_, err := usecase.GetPostLikes(ctx, postID, viewerID, 5, 1)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetPostLikes")
+	})
+}
+
+func TestGetLikedPosts(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	viewerID := int64(2)
+
+	t.Run("Success GetLikedPosts - Default Page", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		posts := model.GetAllPostResponse{Data: []model.PostDetail{{ID: 7}}}
+
+		mockRepo.On("GetLikedPosts", ctx, userID, viewerID, defaultPageSize, 0).Return(posts, nil)
+
+		resp, err := usecase.GetLikedPosts(ctx, userID, viewerID, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, posts, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetLikedPosts - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetLikedPosts", ctx, userID, viewerID, maxPageSize, maxPageSize).Return(model.GetAllPostResponse{}, assert.AnError)
+
+		_, err := usecase.GetLikedPosts(ctx, userID, viewerID, 500, 2)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		mockRepo.AssertExpectations(t)
+	})
+}
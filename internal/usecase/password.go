@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 8
+	// maxPasswordLength is the most bcrypt hashes, longer passwords are rejected
+	// rather than silently truncated
+	maxPasswordLength = 72
+)
+
+// ChangePassword sets a new password after checking the current one and signs
+// the user out of every session, including the current one, by revoking their
+// refresh tokens
+func (u *userUsecase) ChangePassword(ctx context.Context, userID int64, req model.ChangePasswordRequest) (err error) {
+	user, err := u.userRepository.GetUser(ctx, "", "", userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if user.ID == 0 {
+		return model.ErrUserNotFound
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return fmt.Errorf("%w: current password is invalid", model.ErrInvalidInput)
+	}
+
+	password, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = u.userRepository.UpdatePassword(ctx, model.User{
+		ID:        userID,
+		Password:  password,
+		UpdatedAt: time.Now(),
+		UpdatedBy: strconv.FormatInt(userID, 10),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error update password to database")
+		return err
+	}
+	return nil
+}
+
+// ForgotPassword mails a reset link to the user with the email. It succeeds
+// for unknown emails too, and failures to send the link are only logged, so the
+// response does not reveal who has an account.
+func (u *userUsecase) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) (err error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return fmt.Errorf("%w: email is required", model.ErrInvalidInput)
+	}
+
+	user, err := u.userRepository.GetUser(ctx, email, "", 0)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if user.ID == 0 || !strings.EqualFold(user.Email, email) {
+		return nil
+	}
+
+	if err = u.sendPasswordReset(ctx, user); err != nil {
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("error send password reset")
+	}
+	return nil
+}
+
+// sendPasswordReset stores a new reset token for the user and mails its link
+func (u *userUsecase) sendPasswordReset(ctx context.Context, user model.User) (err error) {
+	token := utils.GenerateToken()
+	if token == "" {
+		return errors.New("failed to generate reset token")
+	}
+
+	now := time.Now()
+	err = u.userRepository.CreatePasswordReset(ctx, model.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiredAt: now.Add(u.passwordResetTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error insert password reset to database")
+		return err
+	}
+
+	link, err := withToken(u.passwordResetURL, token)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to choose a new password. It expires in %s and can only be used once.\n\n%s\n\nIf you did not ask to reset your password, you can ignore this email.\n",
+			user.Username, u.passwordResetTTL, link),
+	})
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword, the
+// token is used up and the user is signed out of every session
+func (u *userUsecase) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) (err error) {
+	if req.Token == "" {
+		return fmt.Errorf("%w: token is required", model.ErrInvalidInput)
+	}
+
+	password, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	userID, err := u.userRepository.ResetPassword(ctx, utils.HashToken(req.Token), password, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("error reset password to database")
+		return err
+	}
+	if userID == 0 {
+		return fmt.Errorf("%w: reset token is invalid or expired", model.ErrInvalidInput)
+	}
+	return nil
+}
+
+// hashPassword checks the length of the new password and returns its bcrypt hash
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("%w: password must be at least %d characters", model.ErrInvalidInput, minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		return "", fmt.Errorf("%w: password must be at most %d bytes", model.ErrInvalidInput, maxPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// withToken adds the token to the query of the link
+func withToken(link, token string) (string, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Set("token", token)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
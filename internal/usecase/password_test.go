@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	user := model.User{ID: 1, Email: "alice@example.com", Username: "alice", Password: string(hash)}
+
+	t.Run("Success ChangePassword", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+		mockRepo.On("UpdatePassword", ctx, mock.MatchedBy(func(updated model.User) bool {
+			return updated.ID == 1 && bcrypt.CompareHashAndPassword([]byte(updated.Password), []byte("new-password")) == nil
+		})).Return(nil)
+
+		err := usecase.ChangePassword(ctx, 1, model.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail ChangePassword - Wrong Current Password", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+
+		err := usecase.ChangePassword(ctx, 1, model.ChangePasswordRequest{CurrentPassword: "guess", NewPassword: "new-password"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdatePassword")
+	})
+
+	t.Run("Fail ChangePassword - New Password Too Short", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUser", ctx, "", "", int64(1)).Return(user, nil)
+
+		err := usecase.ChangePassword(ctx, 1, model.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "short"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdatePassword")
+	})
+}
+
+func TestForgotPassword(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: 1, Email: "alice@example.com", Username: "alice"}
+
+	t.Run("Success ForgotPassword - Mails Token", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		outbox := mailer.NewMemory()
+		usecase := &userUsecase{userRepository: mockRepo, mailer: outbox, passwordResetTTL: time.Hour, passwordResetURL: "http://blog.test/reset?lang=en"}
+
+		var stored model.PasswordReset
+		mockRepo.On("GetUser", ctx, "alice@example.com", "", int64(0)).Return(user, nil)
+		mockRepo.On("CreatePasswordReset", ctx, mock.AnythingOfType("model.PasswordReset")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(model.PasswordReset) }).
+			Return(nil)
+
+		err := usecase.ForgotPassword(ctx, model.ForgotPasswordRequest{Email: " alice@example.com "})
+
+		assert.NoError(t, err)
+		messages := outbox.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "alice@example.com", messages[0].To)
+
+		start := strings.Index(messages[0].Body, "token=") + len("token=")
+		token := messages[0].Body[start : start+64]
+		assert.Contains(t, messages[0].Body, "http://blog.test/reset?lang=en&token="+token)
+		assert.Equal(t, utils.HashToken(token), stored.TokenHash)
+		assert.Equal(t, int64(1), stored.UserID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiredAt, time.Minute)
+	})
+
+	t.Run("Success ForgotPassword - Unknown Email", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		outbox := mailer.NewMemory()
+		usecase := &userUsecase{userRepository: mockRepo, mailer: outbox}
+
+		mockRepo.On("GetUser", ctx, "ghost@example.com", "", int64(0)).Return(model.User{}, nil)
+
+		err := usecase.ForgotPassword(ctx, model.ForgotPasswordRequest{Email: "ghost@example.com"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, outbox.Messages())
+		mockRepo.AssertNotCalled(t, "CreatePasswordReset")
+	})
+
+	t.Run("Success ForgotPassword - Mail Failure Is Hidden", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo, mailer: failingMailer{}, passwordResetTTL: time.Hour, passwordResetURL: "http://blog.test/reset"}
+
+		mockRepo.On("GetUser", ctx, "alice@example.com", "", int64(0)).Return(user, nil)
+		mockRepo.On("CreatePasswordReset", ctx, mock.AnythingOfType("model.PasswordReset")).Return(nil)
+
+		err := usecase.ForgotPassword(ctx, model.ForgotPasswordRequest{Email: "alice@example.com"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// failingMailer fails to send every message
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return assert.AnError
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success ResetPassword", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("ResetPassword", ctx, utils.HashToken("token"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+
+		err := usecase.ResetPassword(ctx, model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail ResetPassword - Invalid Token", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("ResetPassword", ctx, utils.HashToken("token"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+
+		err := usecase.ResetPassword(ctx, model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
@@ -3,107 +3,381 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
 )
 
-func (u *postUsecase) CreatePost(ctx context.Context, userID int64, req model.CreatePostRequest) (err error) {
-	postHashtags := strings.Join(req.PostHashtags, ",")
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// CreatePost saves a new post once the content filters accept it. Posts the
+// filters hold for moderation are saved as pending, whatever status was requested.
+func (u *postUsecase) CreatePost(ctx context.Context, userID int64, req model.CreatePostRequest) (status model.PostStatus, err error) {
+	postHashtags, err := normalizeHashtags(req.PostHashtags)
+	if err != nil {
+		return "", err
+	}
+
+	status = req.Status
+	if status == "" {
+		status = model.PostStatusPublished
+	}
 
 	now := time.Now()
+	publishAt, err := resolvePublishAt(status, req.PublishAt, nil, now)
+	if err != nil {
+		return "", err
+	}
+
+	action, err := u.checkContent(ctx, userID, model.ContentKindPost, 0, req.PostTitle+"\n"+req.PostContent)
+	if err != nil {
+		return "", err
+	}
+
+	if action == filter.ActionModerate {
+		status = model.PostStatusPending
+		publishAt = nil
+	}
+
 	model := model.Post{
 		UserID:       userID,
 		PostTitle:    req.PostTitle,
 		PostContent:  req.PostContent,
 		PostHashtags: postHashtags,
+		Status:       status,
+		PublishAt:    publishAt,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		CreatedBy:    strconv.FormatInt(userID, 10),
 		UpdatedBy:    strconv.FormatInt(userID, 10),
 	}
 
-	_, err = u.postRepository.CreatePost(ctx, model)
+	_, err = u.postRepository.CreatePost(ctx, model, newPostRevision(0, userID, req.PostTitle, req.PostContent, postHashtags, now))
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+func (u *postUsecase) UpdatePost(ctx context.Context, postID, userID int64, req model.UpdatePostRequest) (status model.PostStatus, err error) {
+	post, err := u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return "", err
+	}
+
+	hashtags, err := normalizeHashtags(req.PostHashtags)
+	if err != nil {
+		return "", err
+	}
+
+	return u.editPost(ctx, post, userID, req.PostTitle, req.PostContent, hashtags)
+}
+
+// editPost saves the new content of the post once the content filters accept it
+// and records it as a new revision. An edit the filters hold for moderation takes
+// the post down to pending until a moderator approves it.
+// Posts created before revisions existed get their current state stored first,
+// so the history always starts from the original content. The post and its
+// revisions are saved together.
+func (u *postUsecase) editPost(ctx context.Context, post model.Post, editorID int64, title, content string, hashtags []string) (status model.PostStatus, err error) {
+	action, err := u.checkContent(ctx, editorID, model.ContentKindPost, post.ID, title+"\n"+content)
+	if err != nil {
+		return "", err
+	}
+
+	count, err := u.postRepository.CountPostRevisions(ctx, post.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("error count post revisions to database")
+		return "", err
+	}
+
+	revisions := []model.PostRevision{}
+	if count == 0 {
+		revisions = append(revisions, newPostRevision(post.ID, post.UserID, post.PostTitle, post.PostContent, post.PostHashtags, post.UpdatedAt))
+	}
+
+	if action == filter.ActionModerate {
+		post.Status = model.PostStatusPending
+		post.PublishAt = nil
+	}
+
+	now := time.Now()
+	post.PostTitle = title
+	post.PostContent = content
+	post.PostHashtags = hashtags
+	post.UpdatedAt = now
+	post.UpdatedBy = strconv.FormatInt(editorID, 10)
+
+	revisions = append(revisions, newPostRevision(post.ID, editorID, title, content, hashtags, now))
+	err = u.postRepository.UpdatePost(ctx, post, revisions)
+	if err != nil {
+		return "", err
+	}
+	return post.Status, nil
+}
+
+func (u *postUsecase) DeletePost(ctx context.Context, postID, userID int64) (err error) {
+	post, err := u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	post.DeletedAt = &now
+	post.UpdatedAt = now
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.DeletePost(ctx, post)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (u *postUsecase) GetPostByID(ctx context.Context, postID int64) (post model.GetPostResponse, err error) {
-	postDetail, err := u.postRepository.GetPostByID(ctx, postID)
+// getOwnedPost returns the post only when it exists and belongs to userID.
+// Trashed posts are treated as missing unless inTrash is set, in which case
+// only trashed posts are accepted.
+func (u *postUsecase) getOwnedPost(ctx context.Context, postID, userID int64, inTrash bool) (post model.Post, err error) {
+	post, err = u.postRepository.GetPost(ctx, postID)
 	if err != nil {
+		log.Error().Err(err).Msg("error get post from database")
 		return
 	}
 
-	likeCount, err := u.postRepository.CountLikeByPostID(ctx, postID)
+	if post.ID == 0 || (post.DeletedAt != nil && !inTrash) {
+		return post, model.ErrPostNotFound
+	}
+
+	if post.UserID != userID {
+		return post, model.ErrForbidden
+	}
+
+	if post.DeletedAt == nil && inTrash {
+		return post, model.ErrNotInTrash
+	}
+	return post, nil
+}
+
+// GetPostByID returns the post with its first comment threads, nested as a tree
+// when threaded is set and as a flat list carrying depth and parent otherwise.
+// It records the view, views of the author are not counted.
+func (u *postUsecase) GetPostByID(ctx context.Context, postID, viewerID int64, viewerIP string, threaded bool) (post model.GetPostResponse, err error) {
+	postDetail, err := u.postRepository.GetPostByID(ctx, postID, viewerID)
 	if err != nil {
-		log.Error().Err(err).Msg("error count like to database")
 		return
 	}
+	if postDetail.ID == 0 {
+		return post, model.ErrPostNotFound
+	}
 
-	comments, err := u.postRepository.GetCommentsByPostID(ctx, postID)
+	if u.viewCounter != nil {
+		if postDetail.UserID != viewerID {
+			u.viewCounter.Record(postID, viewerKey(viewerID, viewerIP))
+		}
+		postDetail.ViewCount += u.viewCounter.Pending(postID)
+	}
+
+	comments, err := u.getCommentThreads(ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: u.commentEmbedLimit}, threaded)
 	if err != nil {
-		log.Error().Err(err).Msg("error get comment to database")
 		return
 	}
 
+	counts, viewerReaction, err := u.postRepository.GetPostReactions(ctx, postID, viewerID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post reactions from database")
+		return
+	}
+	reactions := map[string]int{model.ReactionLike: 0}
+	for _, reactionType := range u.reactionTypes {
+		reactions[reactionType] = 0
+	}
+	for reaction, count := range counts {
+		reactions[reaction] = count
+	}
+
 	post = model.GetPostResponse{
-		PostDetail: model.PostDetail{
-			ID:           postDetail.ID,
-			UserID:       postDetail.UserID,
-			Username:     postDetail.Username,
-			PostTitle:    postDetail.PostTitle,
-			PostContent:  postDetail.PostContent,
-			PostHashtags: postDetail.PostHashtags,
-			IsLiked:      postDetail.IsLiked,
-		},
-		LikeCount: likeCount,
-		Comments:  comments,
+		PostDetail:     postDetail,
+		LikeCount:      postDetail.LikeCount,
+		ViewCount:      postDetail.ViewCount,
+		Reactions:      reactions,
+		ViewerReaction: viewerReaction,
+		Comments:       comments.Data,
+	}
+	if comments.Pagination.NextCursor != "" {
+		post.MoreComments = fmt.Sprintf("/api/posts/%d/comments?cursor=%s", postID, comments.Pagination.NextCursor)
+		if threaded {
+			post.MoreComments += "&comment-view=tree"
+		}
 	}
 
 	return
 }
 
-func (u *postUsecase) GetAllPost(ctx context.Context, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error) {
-	limit := pageSize
-	offset := pageSize * (pageIndex - 1)
-	posts, err = u.postRepository.GetAllPost(ctx, limit, offset)
+// viewerKey identifies a viewer for view deduplication, signed in viewers by
+// their user id whatever network they use and anonymous viewers by their IP
+// address. It is empty when neither is known.
+func viewerKey(viewerID int64, ip string) string {
+	switch {
+	case viewerID != 0:
+		return "user:" + strconv.FormatInt(viewerID, 10)
+	case ip != "":
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// GetAllPost pages through the posts visible to the viewer that match the filter.
+// A positive pageIndex selects the legacy offset mode, otherwise the page starts
+// after the cursor (or at the start of the listing when the cursor is empty).
+func (u *postUsecase) GetAllPost(ctx context.Context, viewerID int64, filter model.PostFilter, pageSize, pageIndex int, cursor string) (posts model.GetAllPostResponse, err error) {
+	filter, err = normalizePostFilter(filter)
+	if err != nil {
+		return
+	}
+
+	if cursor != "" && pageIndex > 0 {
+		return posts, fmt.Errorf("%w: cursor and page-index cannot be combined", model.ErrInvalidInput)
+	}
+
+	page := model.PageQuery{Limit: normalizePageSize(pageSize)}
+	if pageIndex > 0 {
+		page.Offset = page.Limit * (pageIndex - 1)
+	}
+	if cursor != "" {
+		decoded, decodeErr := utils.DecodeCursor(cursor)
+		if decodeErr != nil {
+			return posts, fmt.Errorf("%w: malformed cursor", model.ErrInvalidInput)
+		}
+		if cursorSort(decoded) != filter.Sort {
+			return posts, fmt.Errorf("%w: cursor was issued for another sort", model.ErrInvalidInput)
+		}
+		page.Cursor = &decoded
+	}
+
+	posts, err = u.postRepository.GetAllPost(ctx, viewerID, filter, page)
 	if err != nil {
 		return
 	}
+
+	posts.Pagination.NextCursor, posts.Pagination.PrevCursor = pageCursors(posts.Data, page, posts.Pagination.HasMore, func(post model.PostDetail, backward bool) model.Cursor {
+		return postCursor(post, filter.Sort, backward)
+	})
 	return
 }
 
-func (u *postUsecase) CreateComment(ctx context.Context, postID, userID int64, request model.CreateCommentRequest) (err error) {
-	now := time.Now()
-	comment := model.Comment{
-		PostID:         postID,
-		UserID:         userID,
-		CommentContent: request.CommentContent,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-		CreatedBy:      strconv.FormatInt(userID, 10),
-		UpdatedBy:      strconv.FormatInt(userID, 10),
+// GetFeed pages through the posts of the authors the viewer follows, newest
+// first, by cursor
+func (u *postUsecase) GetFeed(ctx context.Context, viewerID int64, pageSize int, cursor string) (posts model.GetAllPostResponse, err error) {
+	return u.GetAllPost(ctx, viewerID, model.PostFilter{FollowedBy: viewerID, Sort: model.PostSortNewest}, pageSize, 0, cursor)
+}
+
+// normalizePostFilter validates the filter against the allowed sorts and normalizes its values
+func normalizePostFilter(filter model.PostFilter) (model.PostFilter, error) {
+	if filter.Sort == "" {
+		filter.Sort = model.PostSortUpdated
+	}
+	switch filter.Sort {
+	case model.PostSortUpdated, model.PostSortNewest, model.PostSortOldest, model.PostSortMostLiked, model.PostSortMostCommented:
+	default:
+		return filter, fmt.Errorf("%w: unknown sort %q", model.ErrInvalidInput, filter.Sort)
 	}
 
-	_, err = u.postRepository.CreateComment(ctx, comment)
-	if err != nil {
-		return err
+	filter.Author = strings.TrimSpace(filter.Author)
+	if filter.Hashtag != "" {
+		filter.Hashtag = normalizeHashtag(filter.Hashtag)
+		if filter.Hashtag == "" {
+			return filter, fmt.Errorf("%w: hashtag is empty", model.ErrInvalidInput)
+		}
 	}
 
-	return nil
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("%w: from must be before to", model.ErrInvalidInput)
+	}
+	return filter, nil
+}
+
+// normalizePageSize falls back to the default page size and caps it at the maximum
+func normalizePageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		return maxPageSize
+	}
+	return pageSize
 }
 
+// pageCursors returns the cursors of the pages after and before the fetched items,
+// cursorOf builds the cursor pointing at an item
+func pageCursors[T any](data []T, page model.PageQuery, hasMore bool, cursorOf func(item T, backward bool) model.Cursor) (next, prev string) {
+	if len(data) == 0 {
+		return "", ""
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if hasMore || backward {
+		next = utils.EncodeCursor(cursorOf(data[len(data)-1], false))
+	}
+	if (hasMore && backward) || (!backward && (page.Cursor != nil || page.Offset > 0)) {
+		prev = utils.EncodeCursor(cursorOf(data[0], true))
+	}
+	return next, prev
+}
+
+// postCursor returns the cursor pointing at the post for the given sort
+func postCursor(post model.PostDetail, sort model.PostSort, backward bool) model.Cursor {
+	cursor := model.Cursor{Sort: string(sort), ID: post.ID, Backward: backward}
+	switch sort {
+	case model.PostSortNewest, model.PostSortOldest:
+		cursor.Time = post.CreatedAt
+	case model.PostSortMostLiked:
+		cursor.Count = int64(post.LikeCount)
+	case model.PostSortMostCommented:
+		cursor.Count = int64(post.CommentCount)
+	default:
+		cursor.Time = post.UpdatedAt
+	}
+	return cursor
+}
+
+// cursorSort returns the sort a cursor was issued for, cursors without one predate sorting
+func cursorSort(cursor model.Cursor) model.PostSort {
+	if cursor.Sort == "" {
+		return model.PostSortUpdated
+	}
+	return model.PostSort(cursor.Sort)
+}
+
+// UpsertUserActivity sets, replaces or withdraws the reaction of the user to a
+// post. Reactions outside the configured set are rejected.
 func (u *postUsecase) UpsertUserActivity(ctx context.Context, postID, userID int64, request model.UserActivityRequest) (err error) {
+	reaction := strings.ToLower(strings.TrimSpace(request.Reaction))
+	if reaction == "" && request.IsLiked {
+		reaction = model.ReactionLike
+	}
+	if reaction != "" && !u.validReaction(reaction) {
+		return fmt.Errorf("%w: unknown reaction %q", model.ErrInvalidInput, reaction)
+	}
+
+	_, err = u.getVisiblePost(ctx, postID, userID)
+	if err != nil {
+		return
+	}
+
 	now := time.Now()
 	userActivityReq := model.UserActivity{
 		PostID:    postID,
 		UserID:    userID,
-		IsLiked:   request.IsLiked,
+		Reaction:  reaction,
 		CreatedAt: now,
 		UpdatedAt: now,
 		CreatedBy: strconv.FormatInt(userID, 10),
@@ -117,7 +391,7 @@ func (u *postUsecase) UpsertUserActivity(ctx context.Context, postID, userID int
 
 	if userActivity.ID == 0 {
 		// create user activity
-		if !request.IsLiked {
+		if reaction == "" {
 			return errors.New("never liked this post")
 		}
 		_, err = u.postRepository.CreateUserActivity(ctx, userActivityReq)
@@ -131,3 +405,16 @@ func (u *postUsecase) UpsertUserActivity(ctx context.Context, postID, userID int
 
 	return nil
 }
+
+// validReaction reports whether the reaction is one of the configured types
+func (u *postUsecase) validReaction(reaction string) bool {
+	if reaction == model.ReactionLike {
+		return true
+	}
+	for _, reactionType := range u.reactionTypes {
+		if reaction == reactionType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// GetPendingPosts pages through the posts held for moderation, only moderators may list them
+func (u *postUsecase) GetPendingPosts(ctx context.Context, userID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error) {
+	if !slices.Contains(u.moderatorIDs, userID) {
+		return posts, model.ErrForbidden
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	posts, err = u.postRepository.GetPendingPosts(ctx, userID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get pending posts from database")
+		return
+	}
+	return
+}
+
+// ApprovePost publishes a pending post
+func (u *postUsecase) ApprovePost(ctx context.Context, postID, userID int64) (err error) {
+	post, err := u.getPendingPost(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	post.Status = model.PostStatusPublished
+	post.PublishAt = &now
+	post.UpdatedAt = now
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.UpdatePostStatus(ctx, post)
+	if err != nil {
+		return err
+	}
+
+	u.trainContentFilter(ctx, post.PostTitle+"\n"+post.PostContent, false)
+	return nil
+}
+
+// RejectPost moves a pending post to the trash of its author. Restoring it from
+// the trash brings it back to the moderation queue.
+func (u *postUsecase) RejectPost(ctx context.Context, postID, userID int64) (err error) {
+	post, err := u.getPendingPost(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	post.DeletedAt = &now
+	post.UpdatedAt = now
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.DeletePost(ctx, post)
+	if err != nil {
+		return err
+	}
+
+	u.trainContentFilter(ctx, post.PostTitle+"\n"+post.PostContent, true)
+	return nil
+}
+
+// getPendingPost returns the post for the moderator to decide on
+func (u *postUsecase) getPendingPost(ctx context.Context, postID, userID int64) (post model.Post, err error) {
+	if !slices.Contains(u.moderatorIDs, userID) {
+		return post, model.ErrForbidden
+	}
+
+	post, err = u.postRepository.GetPost(ctx, postID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post from database")
+		return
+	}
+
+	if post.ID == 0 || post.DeletedAt != nil {
+		return post, model.ErrPostNotFound
+	}
+
+	if post.Status != model.PostStatusPending {
+		return post, fmt.Errorf("%w: post is not pending", model.ErrInvalidInput)
+	}
+	return post, nil
+}
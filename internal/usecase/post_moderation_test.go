@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetPendingPosts(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := int64(9)
+
+	t.Run("Success GetPendingPosts", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, moderatorIDs: []int64{moderatorID}}
+		posts := model.GetAllPostResponse{Data: []model.PostDetail{{ID: 1, Status: model.PostStatusPending}}}
+
+		mockRepo.On("GetPendingPosts", ctx, moderatorID, 5, 5).Return(posts, nil)
+
+		resp, err := usecase.GetPendingPosts(ctx, moderatorID, 5, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, posts, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPendingPosts - Not Moderator", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, moderatorIDs: []int64{moderatorID}}
+
+		_, err := usecase.GetPendingPosts(ctx, 2, 5, 1)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "GetPendingPosts")
+	})
+}
+
+func TestModeratePost(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	moderatorID := int64(9)
+	pending := model.Post{ID: postID, UserID: 2, PostTitle: "Cheap pills", PostContent: "Buy now", Status: model.PostStatusPending}
+
+	t.Run("Success ApprovePost", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		trainer := &recordingTrainer{}
+		usecase := &postUsecase{postRepository: mockRepo, contentTrainer: trainer, moderatorIDs: []int64{moderatorID}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(pending, nil)
+		mockRepo.On("UpdatePostStatus", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusPublished && post.PublishAt != nil && post.UpdatedBy == "9"
+		})).Return(nil)
+
+		err := usecase.ApprovePost(ctx, postID, moderatorID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []trainedSample{{text: "Cheap pills\nBuy now", spam: false}}, trainer.samples)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success RejectPost", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		trainer := &recordingTrainer{}
+		usecase := &postUsecase{postRepository: mockRepo, contentTrainer: trainer, moderatorIDs: []int64{moderatorID}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(pending, nil)
+		mockRepo.On("DeletePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.DeletedAt != nil && post.Status == model.PostStatusPending
+		})).Return(nil)
+
+		err := usecase.RejectPost(ctx, postID, moderatorID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []trainedSample{{text: "Cheap pills\nBuy now", spam: true}}, trainer.samples)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail ApprovePost - Not Moderator", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, moderatorIDs: []int64{moderatorID}}
+
+		err := usecase.ApprovePost(ctx, postID, pending.UserID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "UpdatePostStatus")
+	})
+
+	t.Run("Fail ApprovePost - Not Pending", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, moderatorIDs: []int64{moderatorID}}
+		publishedAt := time.Now()
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2, Status: model.PostStatusPublished, PublishAt: &publishedAt}, nil)
+
+		err := usecase.ApprovePost(ctx, postID, moderatorID)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdatePostStatus")
+	})
+
+	t.Run("Fail RejectPost - Post Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, moderatorIDs: []int64{moderatorID}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{}, nil)
+
+		err := usecase.RejectPost(ctx, postID, moderatorID)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertNotCalled(t, "DeletePost")
+	})
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// postStatusTransitions lists the statuses a post may move to from its current status
+var postStatusTransitions = map[model.PostStatus][]model.PostStatus{
+	model.PostStatusDraft:     {model.PostStatusScheduled, model.PostStatusPublished},
+	model.PostStatusScheduled: {model.PostStatusDraft, model.PostStatusScheduled, model.PostStatusPublished},
+	model.PostStatusPublished: {model.PostStatusDraft, model.PostStatusArchived},
+	model.PostStatusArchived:  {model.PostStatusDraft, model.PostStatusPublished},
+}
+
+func (u *postUsecase) UpdatePostStatus(ctx context.Context, postID, userID int64, req model.UpdatePostStatusRequest) (err error) {
+	post, err := u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return err
+	}
+
+	if !canTransitionPostStatus(post.Status, req.Status) {
+		return fmt.Errorf("%w: cannot change post status from %s to %s", model.ErrInvalidInput, post.Status, req.Status)
+	}
+
+	now := time.Now()
+	publishAt, err := resolvePublishAt(req.Status, req.PublishAt, post.PublishAt, now)
+	if err != nil {
+		return err
+	}
+
+	post.Status = req.Status
+	post.PublishAt = publishAt
+	post.UpdatedAt = now
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.UpdatePostStatus(ctx, post)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PublishScheduledPosts publishes every scheduled post that is due at the given time
+func (u *postUsecase) PublishScheduledPosts(ctx context.Context, now time.Time) (err error) {
+	published, err := u.postRepository.PublishDuePosts(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	if published > 0 {
+		log.Info().Int64("published", published).Msg("published scheduled posts")
+	}
+	return nil
+}
+
+func canTransitionPostStatus(from, to model.PostStatus) bool {
+	for _, status := range postStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePublishAt validates the requested publish time for the target status.
+// Scheduled posts need a publish time in the future, published posts go live now,
+// drafts have no publish time and archived posts keep their original one.
+func resolvePublishAt(status model.PostStatus, requested, current *time.Time, now time.Time) (*time.Time, error) {
+	switch status {
+	case model.PostStatusDraft:
+		return nil, nil
+	case model.PostStatusScheduled:
+		if requested == nil || !requested.After(now) {
+			return nil, fmt.Errorf("%w: scheduled posts need a publishAt in the future", model.ErrInvalidInput)
+		}
+		return requested, nil
+	case model.PostStatusPublished:
+		return &now, nil
+	case model.PostStatusArchived:
+		return current, nil
+	}
+	return nil, fmt.Errorf("%w: unknown post status %q", model.ErrInvalidInput, status)
+}
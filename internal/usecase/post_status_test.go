@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestUpdatePostStatus(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(1)
+
+	t.Run("Success UpdatePostStatus - Schedule Draft", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		publishAt := time.Now().Add(time.Hour)
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, Status: model.PostStatusDraft}, nil)
+		mockRepo.On("UpdatePostStatus", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusScheduled && post.PublishAt.Equal(publishAt)
+		})).Return(nil)
+
+		err := usecase.UpdatePostStatus(ctx, postID, userID, model.UpdatePostStatusRequest{Status: model.PostStatusScheduled, PublishAt: &publishAt})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success UpdatePostStatus - Archive Keeps Publish Time", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		publishedAt := time.Now().Add(-time.Hour)
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, Status: model.PostStatusPublished, PublishAt: &publishedAt}, nil)
+		mockRepo.On("UpdatePostStatus", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusArchived && post.PublishAt.Equal(publishedAt)
+		})).Return(nil)
+
+		err := usecase.UpdatePostStatus(ctx, postID, userID, model.UpdatePostStatusRequest{Status: model.PostStatusArchived})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePostStatus - Invalid Transition", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, Status: model.PostStatusDraft}, nil)
+
+		err := usecase.UpdatePostStatus(ctx, postID, userID, model.UpdatePostStatusRequest{Status: model.PostStatusArchived})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePostStatus - Missing Publish Time", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, Status: model.PostStatusDraft}, nil)
+
+		err := usecase.UpdatePostStatus(ctx, postID, userID, model.UpdatePostStatusRequest{Status: model.PostStatusScheduled})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPublishScheduledPosts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockRepo := new(mocks.MockPostRepository)
+	usecase := &postUsecase{postRepository: mockRepo}
+
+	mockRepo.On("PublishDuePosts", ctx, now).Return(int64(1), nil)
+
+	err := usecase.PublishScheduledPosts(ctx, now)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
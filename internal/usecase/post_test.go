@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
 	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+	"github.com/suhriar/blog-mono-api/pkg/viewcount"
 )
 
 func TestCreatePost(t *testing.T) {
@@ -23,21 +27,69 @@ func TestCreatePost(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("CreatePost", ctx, mock.AnythingOfType("model.Post")).Return(int64(1), nil)
+		mockRepo.On("CreatePost", ctx, mock.AnythingOfType("model.Post"), mock.MatchedBy(func(revision model.PostRevision) bool {
+			return *revision.EditorID == userID && revision.PostTitle == req.PostTitle
+		})).Return(int64(1), nil)
 
-		err := usecase.CreatePost(ctx, userID, req)
+		_, err := usecase.CreatePost(ctx, userID, req)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success CreatePost - Draft", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		draftReq := req
+		draftReq.Status = model.PostStatusDraft
+
+		mockRepo.On("CreatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.Status == model.PostStatusDraft && post.PublishAt == nil
+		}), mock.AnythingOfType("model.PostRevision")).Return(int64(1), nil)
+
+		_, err := usecase.CreatePost(ctx, userID, draftReq)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success CreatePost - Normalizes Hashtags", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		tagReq := req
+		tagReq.PostHashtags = []string{" #GoLang ", "golang", "Web   Dev", "", "a,b"}
+
+		mockRepo.On("CreatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return assert.ObjectsAreEqual([]string{"golang", "web dev", "a,b"}, post.PostHashtags)
+		}), mock.AnythingOfType("model.PostRevision")).Return(int64(1), nil)
+
+		_, err := usecase.CreatePost(ctx, userID, tagReq)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail CreatePost - Scheduled In The Past", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		past := time.Now().Add(-time.Hour)
+		scheduledReq := req
+		scheduledReq.Status = model.PostStatusScheduled
+		scheduledReq.PublishAt = &past
+
+		_, err := usecase.CreatePost(ctx, userID, scheduledReq)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Fail CreatePost - Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("CreatePost", ctx, mock.AnythingOfType("model.Post")).Return(int64(0), assert.AnError)
+		mockRepo.On("CreatePost", ctx, mock.AnythingOfType("model.Post"), mock.AnythingOfType("model.PostRevision")).Return(int64(0), assert.AnError)
 
-		err := usecase.CreatePost(ctx, userID, req)
+		_, err := usecase.CreatePost(ctx, userID, req)
 
 		assert.Error(t, err)
 		assert.Equal(t, assert.AnError, err)
@@ -48,6 +100,7 @@ func TestCreatePost(t *testing.T) {
 func TestGetPostByID(t *testing.T) {
 	ctx := context.Background()
 	postID := int64(1)
+	viewerID := int64(2)
 
 	mockPostDetail := model.PostDetail{
 		ID:           postID,
@@ -56,6 +109,8 @@ func TestGetPostByID(t *testing.T) {
 		PostTitle:    "Test Post",
 		PostContent:  "This is a test post content.",
 		PostHashtags: []string{"golang", "testing"},
+		LikeCount:    10,
+		CommentCount: 1,
 		IsLiked:      true,
 	}
 
@@ -65,42 +120,100 @@ func TestGetPostByID(t *testing.T) {
 
 	t.Run("Success GetPostByID", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
-		usecase := &postUsecase{postRepository: mockRepo}
+		usecase := &postUsecase{postRepository: mockRepo, commentEmbedLimit: 20, commentReplyLimit: 10, reactionTypes: []string{"like", "love", "sad"}}
 
-		mockRepo.On("GetPostByID", ctx, postID).Return(mockPostDetail, nil)
-		mockRepo.On("CountLikeByPostID", ctx, postID).Return(10, nil)
-		mockRepo.On("GetCommentsByPostID", ctx, postID).Return(mockComments, nil)
+		mockRepo.On("GetPostByID", ctx, postID, viewerID).Return(mockPostDetail, nil)
+		mockRepo.On("GetComments", ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: 20}).Return(model.GetCommentsResponse{Data: mockComments}, nil)
+		mockRepo.On("GetCommentReplies", ctx, []int64{1}, 10).Return([]model.CommentResponse{}, map[int64]int{}, nil)
+		mockRepo.On("GetPostReactions", ctx, postID, viewerID).Return(map[string]int{"like": 10, "love": 2}, "like", nil)
 
-		post, err := usecase.GetPostByID(ctx, postID)
+		post, err := usecase.GetPostByID(ctx, postID, viewerID, "", false)
 
 		assert.NoError(t, err)
-		assert.Equal(t, postID, post.PostDetail.ID)
+		assert.Equal(t, mockPostDetail, post.PostDetail)
 		assert.Equal(t, 10, post.LikeCount)
+		assert.Equal(t, map[string]int{"like": 10, "love": 2, "sad": 0}, post.Reactions)
+		assert.Equal(t, "like", post.ViewerReaction)
 		assert.Equal(t, mockComments, post.Comments)
+		assert.Empty(t, post.MoreComments)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Fail GetPostByID - Repository Error", func(t *testing.T) {
+	t.Run("Success GetPostByID - More Comments", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, commentEmbedLimit: 1, commentReplyLimit: 10}
+
+		mockRepo.On("GetPostByID", ctx, postID, viewerID).Return(mockPostDetail, nil)
+		mockRepo.On("GetComments", ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: 1}).Return(model.GetCommentsResponse{
+			Data:       mockComments,
+			Pagination: model.Pagination{Limit: 1, Total: 2, HasMore: true},
+		}, nil)
+		mockRepo.On("GetCommentReplies", ctx, []int64{1}, 10).Return([]model.CommentResponse{}, map[int64]int{}, nil)
+		mockRepo.On("GetPostReactions", ctx, postID, viewerID).Return(map[string]int{}, "", nil)
+
+		post, err := usecase.GetPostByID(ctx, postID, viewerID, "", true)
+
+		assert.NoError(t, err)
+		assert.Len(t, post.Comments, 1)
+		assert.True(t, strings.HasPrefix(post.MoreComments, "/api/posts/1/comments?cursor="))
+		assert.True(t, strings.HasSuffix(post.MoreComments, "&comment-view=tree"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success GetPostByID - Records Views", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		viewCounter := viewcount.NewCounter(mockRepo, time.Hour)
+		usecase := &postUsecase{postRepository: mockRepo, commentEmbedLimit: 20, commentReplyLimit: 10, viewCounter: viewCounter}
+		storedViews := mockPostDetail
+		storedViews.ViewCount = 5
+
+		mockRepo.On("GetPostByID", ctx, postID, mock.Anything).Return(storedViews, nil)
+		mockRepo.On("GetComments", ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: 20}).Return(model.GetCommentsResponse{}, nil)
+		mockRepo.On("GetCommentReplies", ctx, []int64{}, 10).Return([]model.CommentResponse{}, map[int64]int{}, nil)
+		mockRepo.On("GetPostReactions", ctx, postID, mock.Anything).Return(map[string]int{}, "", nil)
+
+		post, err := usecase.GetPostByID(ctx, postID, viewerID, "10.0.0.1", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), post.ViewCount)
+		assert.Equal(t, int64(6), post.PostDetail.ViewCount)
+
+		// the same viewer again and the author do not count
+		post, err = usecase.GetPostByID(ctx, postID, viewerID, "10.0.0.2", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), post.ViewCount)
+		post, err = usecase.GetPostByID(ctx, postID, storedViews.UserID, "10.0.0.3", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), post.ViewCount)
+
+		// another user behind the same address counts
+		post, err = usecase.GetPostByID(ctx, postID, 3, "10.0.0.1", false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), post.ViewCount)
+
+		mockRepo.On("IncrementViewCounts", ctx, map[int64]int64{postID: 2}, mock.AnythingOfType("time.Time")).Return(nil)
+		assert.NoError(t, viewCounter.Flush(ctx))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostByID - Not Found", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("GetPostByID", ctx, postID).Return(model.PostDetail{}, assert.AnError)
+		mockRepo.On("GetPostByID", ctx, postID, viewerID).Return(model.PostDetail{}, nil)
 
-		post, err := usecase.GetPostByID(ctx, postID)
+		_, err := usecase.GetPostByID(ctx, postID, viewerID, "", false)
 
-		assert.Error(t, err)
-		assert.Empty(t, post)
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Fail GetPostByID - Count Like Error", func(t *testing.T) {
+	t.Run("Fail GetPostByID - Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("GetPostByID", ctx, postID).Return(mockPostDetail, nil)
-		mockRepo.On("CountLikeByPostID", ctx, postID).Return(0, assert.AnError)
+		mockRepo.On("GetPostByID", ctx, postID, viewerID).Return(model.PostDetail{}, assert.AnError)
 
-		post, err := usecase.GetPostByID(ctx, postID)
+		post, err := usecase.GetPostByID(ctx, postID, viewerID, "", false)
 
 		assert.Error(t, err)
 		assert.Empty(t, post)
@@ -109,13 +222,12 @@ func TestGetPostByID(t *testing.T) {
 
 	t.Run("Fail GetPostByID - Get Comments Error", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
-		usecase := &postUsecase{postRepository: mockRepo}
+		usecase := &postUsecase{postRepository: mockRepo, commentEmbedLimit: 20, commentReplyLimit: 10}
 
-		mockRepo.On("GetPostByID", ctx, postID).Return(mockPostDetail, nil)
-		mockRepo.On("CountLikeByPostID", ctx, postID).Return(10, nil)
-		mockRepo.On("GetCommentsByPostID", ctx, postID).Return([]model.CommentResponse{}, assert.AnError)
+		mockRepo.On("GetPostByID", ctx, postID, viewerID).Return(mockPostDetail, nil)
+		mockRepo.On("GetComments", ctx, postID, model.CommentSortOldest, model.PageQuery{Limit: 20}).Return(model.GetCommentsResponse{}, assert.AnError)
 
-		post, err := usecase.GetPostByID(ctx, postID)
+		post, err := usecase.GetPostByID(ctx, postID, viewerID, "", false)
 
 		assert.Error(t, err)
 		assert.Empty(t, post)
@@ -125,10 +237,12 @@ func TestGetPostByID(t *testing.T) {
 
 func TestGetAllPost(t *testing.T) {
 	ctx := context.Background()
+	viewerID := int64(1)
 	pageSize := 10
 	pageIndex := 1
-	limit := pageSize
-	offset := pageSize * (pageIndex - 1)
+	page := model.PageQuery{Limit: pageSize, Offset: pageSize * (pageIndex - 1)}
+	now := time.Now().UTC()
+	filter := model.PostFilter{Sort: model.PostSortUpdated}
 
 	t.Run("Success GetAllPost", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
@@ -145,62 +259,182 @@ func TestGetAllPost(t *testing.T) {
 			},
 		}
 
-		mockRepo.On("GetAllPost", ctx, limit, offset).Return(expectedPosts, nil)
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, page).Return(expectedPosts, nil)
 
-		posts, err := usecase.GetAllPost(ctx, pageSize, pageIndex)
+		posts, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, pageSize, pageIndex, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedPosts, posts)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Fail GetAllPost - Repository Error", func(t *testing.T) {
+	t.Run("Success GetAllPost - Default And Max Page Size", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("GetAllPost", ctx, limit, offset).Return(model.GetAllPostResponse{}, assert.AnError)
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, model.PageQuery{Limit: defaultPageSize}).Return(model.GetAllPostResponse{}, nil).Once()
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, model.PageQuery{Limit: maxPageSize}).Return(model.GetAllPostResponse{}, nil).Once()
 
-		posts, err := usecase.GetAllPost(ctx, pageSize, pageIndex)
+		_, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, 0, 0, "")
+		assert.NoError(t, err)
 
-		assert.Error(t, err)
-		assert.Equal(t, assert.AnError, err)
-		assert.Empty(t, posts)
+		_, err = usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, maxPageSize+1, 0, "")
+		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
-}
 
-func TestCreateComment(t *testing.T) {
-	ctx := context.Background()
-	postID := int64(1)
-	userID := int64(2)
-	req := model.CreateCommentRequest{
-		CommentContent: "This is a test comment",
-	}
+	t.Run("Success GetAllPost - Cursor Pages", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
 
-	t.Run("Success CreateComment", func(t *testing.T) {
+		first := model.PostDetail{ID: 5, UpdatedAt: now}
+		last := model.PostDetail{ID: 4, UpdatedAt: now.Add(-time.Minute)}
+		cursor := model.Cursor{Time: now.Add(time.Minute), ID: 6}
+
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, model.PageQuery{Limit: 2, Cursor: &cursor}).
+			Return(model.GetAllPostResponse{Data: []model.PostDetail{first, last}, Pagination: model.Pagination{Limit: 2, HasMore: true}}, nil)
+
+		posts, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, 2, 0, utils.EncodeCursor(cursor))
+
+		assert.NoError(t, err)
+		assert.True(t, posts.Pagination.HasMore)
+
+		next, err := utils.DecodeCursor(posts.Pagination.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Cursor{Sort: "updated", Time: last.UpdatedAt, ID: last.ID}, next)
+
+		prev, err := utils.DecodeCursor(posts.Pagination.PrevCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Cursor{Sort: "updated", Time: first.UpdatedAt, ID: first.ID, Backward: true}, prev)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success GetAllPost - First Page Has No Prev Cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, model.PageQuery{Limit: 2}).
+			Return(model.GetAllPostResponse{Data: []model.PostDetail{{ID: 2, UpdatedAt: now}}, Pagination: model.Pagination{Limit: 2}}, nil)
+
+		posts, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, 2, 0, "")
+
+		assert.NoError(t, err)
+		assert.False(t, posts.Pagination.HasMore)
+		assert.Empty(t, posts.Pagination.NextCursor)
+		assert.Empty(t, posts.Pagination.PrevCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success GetAllPost - Normalizes Filter", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("CreateComment", ctx, mock.AnythingOfType("model.Comment")).Return(int64(1), nil)
+		from := now.Add(-time.Hour)
+		expectedFilter := model.PostFilter{Author: "user1", Hashtag: "golang", From: &from, To: &now, Sort: model.PostSortMostLiked}
+		mockRepo.On("GetAllPost", ctx, viewerID, expectedFilter, model.PageQuery{Limit: pageSize}).
+			Return(model.GetAllPostResponse{Data: []model.PostDetail{{ID: 3, LikeCount: 7}}, Pagination: model.Pagination{Limit: pageSize, HasMore: true}}, nil)
 
-		err := usecase.CreateComment(ctx, postID, userID, req)
+		posts, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{Author: " user1 ", Hashtag: "#GoLang", From: &from, To: &now, Sort: model.PostSortMostLiked}, pageSize, 0, "")
 
 		assert.NoError(t, err)
+		next, err := utils.DecodeCursor(posts.Pagination.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Cursor{Sort: "most_liked", Count: 7, ID: 3}, next)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetAllPost - Invalid Filter", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		earlier := now.Add(-time.Hour)
+
+		_, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{Sort: "random"}, pageSize, 0, "")
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+
+		_, err = usecase.GetAllPost(ctx, viewerID, model.PostFilter{From: &now, To: &earlier}, pageSize, 0, "")
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+
+		_, err = usecase.GetAllPost(ctx, viewerID, model.PostFilter{Hashtag: "#"}, pageSize, 0, "")
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Fail CreateComment - Repository Error", func(t *testing.T) {
+	t.Run("Fail GetAllPost - Cursor From Another Sort", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("CreateComment", ctx, mock.AnythingOfType("model.Comment")).Return(int64(0), assert.AnError)
+		_, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{Sort: model.PostSortOldest}, pageSize, 0, utils.EncodeCursor(model.Cursor{ID: 1}))
 
-		err := usecase.CreateComment(ctx, postID, userID, req)
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetAllPost - Malformed Cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		_, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, pageSize, 0, "not a cursor!")
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetAllPost - Cursor With Page Index", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		_, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, pageSize, pageIndex, utils.EncodeCursor(model.Cursor{ID: 1}))
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetAllPost - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, page).Return(model.GetAllPostResponse{}, assert.AnError)
+
+		posts, err := usecase.GetAllPost(ctx, viewerID, model.PostFilter{}, pageSize, pageIndex, "")
 
 		assert.Error(t, err)
 		assert.Equal(t, assert.AnError, err)
+		assert.Empty(t, posts)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetFeed(t *testing.T) {
+	ctx := context.Background()
+	viewerID := int64(1)
+	now := time.Now().UTC()
+	filter := model.PostFilter{FollowedBy: viewerID, Sort: model.PostSortNewest}
+
+	t.Run("Success GetFeed", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		cursor := model.Cursor{Sort: string(model.PostSortNewest), Time: now, ID: 9}
+		page := model.PageQuery{Limit: 1, Cursor: &cursor}
+		mockRepo.On("GetAllPost", ctx, viewerID, filter, page).Return(model.GetAllPostResponse{
+			Data:       []model.PostDetail{{ID: 8, CreatedAt: now}},
+			Pagination: model.Pagination{Limit: 1, HasMore: true},
+		}, nil)
+
+		posts, err := usecase.GetFeed(ctx, viewerID, 1, utils.EncodeCursor(cursor))
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.EncodeCursor(model.Cursor{Sort: string(model.PostSortNewest), Time: now, ID: 8}), posts.Pagination.NextCursor)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Fail GetFeed - Cursor Of Another Listing", func(t *testing.T) {
+		usecase := &postUsecase{}
+
+		_, err := usecase.GetFeed(ctx, viewerID, 1, utils.EncodeCursor(model.Cursor{ID: 1}))
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
 }
 
 func TestUpsertUserActivity(t *testing.T) {
@@ -208,13 +442,18 @@ func TestUpsertUserActivity(t *testing.T) {
 	postID := int64(1)
 	userID := int64(1)
 	request := model.UserActivityRequest{IsLiked: true}
+	publishedPost := model.Post{ID: postID, UserID: 2, Status: model.PostStatusPublished}
 
 	t.Run("Success CreateUserActivity", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
 		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 0}, nil)
-		mockRepo.On("CreateUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(int64(1), nil)
+		mockRepo.On("CreateUserActivity", ctx, mock.MatchedBy(func(activity model.UserActivity) bool {
+			return activity.Reaction == model.ReactionLike
+		})).Return(int64(1), nil)
 
 		err := usecase.UpsertUserActivity(ctx, postID, userID, request)
 
@@ -222,14 +461,67 @@ func TestUpsertUserActivity(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success CreateUserActivity - Configured Reaction", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, reactionTypes: []string{"like", "insightful"}}
+
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
+		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 0}, nil)
+		mockRepo.On("CreateUserActivity", ctx, mock.MatchedBy(func(activity model.UserActivity) bool {
+			return activity.Reaction == "insightful"
+		})).Return(int64(1), nil)
+
+		err := usecase.UpsertUserActivity(ctx, postID, userID, model.UserActivityRequest{Reaction: " Insightful "})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpsertUserActivity - Unknown Reaction", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo, reactionTypes: []string{"like", "love"}}
+
+		err := usecase.UpsertUserActivity(ctx, postID, userID, model.UserActivityRequest{Reaction: "angry"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpsertUserActivity - Post Not Visible", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2, Status: model.PostStatusDraft}, nil)
+
+		err := usecase.UpsertUserActivity(ctx, postID, userID, request)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpsertUserActivity - Blocked By Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(true, nil)
+
+		err := usecase.UpsertUserActivity(ctx, postID, userID, request)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertNotCalled(t, "GetUserActivity")
+	})
+
 	t.Run("Fail CreateUserActivity - Never Liked", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
-		request.IsLiked = false
 
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
 		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 0}, nil)
 
-		err := usecase.UpsertUserActivity(ctx, postID, userID, request)
+		err := usecase.UpsertUserActivity(ctx, postID, userID, model.UserActivityRequest{IsLiked: false})
 
 		assert.Error(t, err)
 		assert.Equal(t, "never liked this post", err.Error())
@@ -240,7 +532,9 @@ func TestUpsertUserActivity(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
-		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 1}, nil)
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
+		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 1, Reaction: "love"}, nil)
 		mockRepo.On("UpdateUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(nil)
 
 		err := usecase.UpsertUserActivity(ctx, postID, userID, request)
@@ -249,10 +543,29 @@ func TestUpsertUserActivity(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success UpdateUserActivity - Withdraw Reaction", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
+		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 1, Reaction: "love"}, nil)
+		mockRepo.On("UpdateUserActivity", ctx, mock.MatchedBy(func(activity model.UserActivity) bool {
+			return activity.Reaction == ""
+		})).Return(nil)
+
+		err := usecase.UpsertUserActivity(ctx, postID, userID, model.UserActivityRequest{})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Fail UpdateUserActivity - Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.MockPostRepository)
 		usecase := &postUsecase{postRepository: mockRepo}
 
+		mockRepo.On("GetPost", ctx, postID).Return(publishedPost, nil)
+		mockRepo.On("IsBlocked", ctx, publishedPost.UserID, userID).Return(false, nil)
 		mockRepo.On("GetUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(model.UserActivity{ID: 1}, nil)
 		mockRepo.On("UpdateUserActivity", ctx, mock.AnythingOfType("model.UserActivity")).Return(assert.AnError)
 
@@ -263,3 +576,131 @@ func TestUpsertUserActivity(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUpdatePost(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(1)
+	req := model.UpdatePostRequest{
+		PostTitle:    "Updated Post",
+		PostContent:  "Updated content.",
+		PostHashtags: []string{"golang"},
+	}
+
+	t.Run("Success UpdatePost", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID}, nil)
+		mockRepo.On("CountPostRevisions", ctx, postID).Return(1, nil)
+		mockRepo.On("UpdatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.ID == postID && post.PostTitle == req.PostTitle && post.UpdatedBy == "1"
+		}), mock.MatchedBy(func(revisions []model.PostRevision) bool {
+			return len(revisions) == 1 && revisions[0].PostID == postID && revisions[0].PostTitle == req.PostTitle
+		})).Return(nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success UpdatePost - Records Baseline Revision", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, PostTitle: "Original", PostHashtags: []string{"go"}}, nil)
+		mockRepo.On("CountPostRevisions", ctx, postID).Return(0, nil)
+		mockRepo.On("UpdatePost", ctx, mock.AnythingOfType("model.Post"), mock.MatchedBy(func(revisions []model.PostRevision) bool {
+			return len(revisions) == 2 && revisions[0].PostTitle == "Original" && revisions[1].PostTitle == req.PostTitle
+		})).Return(nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePost - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{}, nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePost - Trashed", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+		deletedAt := time.Now()
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, DeletedAt: &deletedAt}, nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdatePost - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2}, nil)
+
+		_, err := usecase.UpdatePost(ctx, postID, userID, req)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeletePost(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(1)
+
+	t.Run("Success DeletePost", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID}, nil)
+		mockRepo.On("DeletePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.ID == postID && post.DeletedAt != nil
+		})).Return(nil)
+
+		err := usecase.DeletePost(ctx, postID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail DeletePost - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2}, nil)
+
+		err := usecase.DeletePost(ctx, postID, userID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail DeletePost - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID}, nil)
+		mockRepo.On("DeletePost", ctx, mock.AnythingOfType("model.Post")).Return(assert.AnError)
+
+		err := usecase.DeletePost(ctx, postID, userID)
+
+		assert.Equal(t, assert.AnError, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
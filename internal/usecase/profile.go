@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+const (
+	maxDisplayNameLength = 100
+	maxBioLength         = 500
+	maxURLLength         = 500
+	maxLocationLength    = 100
+)
+
+// GetMe returns the profile of the user, including their email
+func (u *userUsecase) GetMe(ctx context.Context, userID int64) (profile model.UserProfile, err error) {
+	profile, err = u.userRepository.GetUserProfile(ctx, userID, "", userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user profile from database")
+		return
+	}
+	if profile.ID == 0 {
+		return profile, model.ErrUserNotFound
+	}
+	return
+}
+
+// UpdateMe changes the profile fields set in the request and returns the
+// updated profile
+func (u *userUsecase) UpdateMe(ctx context.Context, userID int64, req model.UpdateProfileRequest) (profile model.UserProfile, err error) {
+	profile, err = u.GetMe(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	fields := []struct {
+		name   string
+		value  *string
+		target *string
+		max    int
+		isURL  bool
+	}{
+		{"displayName", req.DisplayName, &profile.DisplayName, maxDisplayNameLength, false},
+		{"bio", req.Bio, &profile.Bio, maxBioLength, false},
+		{"avatarUrl", req.AvatarURL, &profile.AvatarURL, maxURLLength, true},
+		{"website", req.Website, &profile.Website, maxURLLength, true},
+		{"location", req.Location, &profile.Location, maxLocationLength, false},
+	}
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*field.value)
+		if utf8.RuneCountInString(value) > field.max {
+			return profile, fmt.Errorf("%w: %s is longer than %d characters", model.ErrInvalidInput, field.name, field.max)
+		}
+		if field.isURL && value != "" && !isWebURL(value) {
+			return profile, fmt.Errorf("%w: %s must be an http or https URL", model.ErrInvalidInput, field.name)
+		}
+		*field.target = value
+	}
+
+	err = u.userRepository.UpdateUserProfile(ctx, model.User{
+		ID:          userID,
+		DisplayName: profile.DisplayName,
+		Bio:         profile.Bio,
+		AvatarURL:   profile.AvatarURL,
+		Website:     profile.Website,
+		Location:    profile.Location,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   strconv.FormatInt(userID, 10),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error update user profile to database")
+		return
+	}
+	return
+}
+
+// GetProfile returns the public profile of the user with the username
+func (u *userUsecase) GetProfile(ctx context.Context, username string, viewerID int64) (profile model.UserProfile, err error) {
+	profile, err = u.userRepository.GetUserProfile(ctx, 0, username, viewerID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user profile from database")
+		return
+	}
+	if profile.ID == 0 {
+		return profile, model.ErrUserNotFound
+	}
+
+	if profile.ID != viewerID {
+		profile.Email = ""
+	}
+	return
+}
+
+// isWebURL reports whether the value is an absolute http or https URL
+func isWebURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
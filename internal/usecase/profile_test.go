@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestGetMe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success GetMe", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+		expected := model.UserProfile{ID: 1, Username: "alice", Email: "alice@example.com"}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(expected, nil)
+
+		profile, err := usecase.GetMe(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, profile)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetMe - User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(model.UserProfile{}, nil)
+
+		_, err := usecase.GetMe(ctx, 1)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+}
+
+func TestUpdateMe(t *testing.T) {
+	ctx := context.Background()
+	current := model.UserProfile{ID: 1, Username: "alice", DisplayName: "Alice", Bio: "Old bio", Location: "Jakarta"}
+
+	t.Run("Success UpdateMe - Only Set Fields", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(current, nil)
+		mockRepo.On("UpdateUserProfile", ctx, mock.MatchedBy(func(user model.User) bool {
+			return user.ID == 1 && user.DisplayName == "Alice" && user.Bio == "New bio" && user.Website == "https://alice.dev" && user.Location == ""
+		})).Return(nil)
+
+		profile, err := usecase.UpdateMe(ctx, 1, model.UpdateProfileRequest{
+			Bio:      stringPtr(" New bio "),
+			Website:  stringPtr("https://alice.dev"),
+			Location: stringPtr(""),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "New bio", profile.Bio)
+		assert.Equal(t, "Alice", profile.DisplayName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail UpdateMe - Invalid Website", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(current, nil)
+
+		_, err := usecase.UpdateMe(ctx, 1, model.UpdateProfileRequest{Website: stringPtr("javascript:alert(1)")})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdateUserProfile")
+	})
+
+	t.Run("Fail UpdateMe - Bio Too Long", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(1), "", int64(1)).Return(current, nil)
+
+		_, err := usecase.UpdateMe(ctx, 1, model.UpdateProfileRequest{Bio: stringPtr(strings.Repeat("a", maxBioLength+1))})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdateUserProfile")
+	})
+}
+
+func TestGetProfile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success GetProfile - Hides Email", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(0), "alice", int64(3)).Return(model.UserProfile{ID: 2, Username: "alice", Email: "alice@example.com", PostCount: 4}, nil)
+
+		profile, err := usecase.GetProfile(ctx, "alice", 3)
+
+		assert.NoError(t, err)
+		assert.Empty(t, profile.Email)
+		assert.Equal(t, 4, profile.PostCount)
+	})
+
+	t.Run("Fail GetProfile - User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("GetUserProfile", ctx, int64(0), "ghost", int64(3)).Return(model.UserProfile{}, nil)
+
+		_, err := usecase.GetProfile(ctx, "ghost", 3)
+
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+}
+
+func TestUserNeverSerializesPassword(t *testing.T) {
+	b, err := json.Marshal(model.User{ID: 1, Password: "hash"})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "hash")
+	assert.NotContains(t, string(b), "password")
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/diff"
+)
+
+func (u *postUsecase) GetPostRevisions(ctx context.Context, postID, viewerID int64) (revisions model.GetPostRevisionsResponse, err error) {
+	_, err = u.getVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		return
+	}
+
+	data, err := u.postRepository.GetPostRevisions(ctx, postID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post revisions from database")
+		return
+	}
+
+	revisions.Data = data
+	return
+}
+
+// GetPostRevision returns the revision together with its diff against the previous revision
+func (u *postUsecase) GetPostRevision(ctx context.Context, postID int64, revision int, viewerID int64) (resp model.PostRevisionDiffResponse, err error) {
+	_, err = u.getVisiblePost(ctx, postID, viewerID)
+	if err != nil {
+		return
+	}
+
+	current, err := u.postRepository.GetPostRevision(ctx, postID, revision)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post revision from database")
+		return
+	}
+	if current.ID == 0 {
+		return resp, model.ErrRevisionNotFound
+	}
+
+	previous, err := u.postRepository.GetPreviousPostRevision(ctx, postID, revision)
+	if err != nil {
+		log.Error().Err(err).Msg("error get previous post revision from database")
+		return
+	}
+
+	added, removed := diffHashtags(previous.PostHashtags, current.PostHashtags)
+	resp = model.PostRevisionDiffResponse{
+		Revision:         current,
+		PreviousRevision: previous.Revision,
+		TitleDiff:        diff.Lines(previous.PostTitle, current.PostTitle),
+		ContentDiff:      diff.Lines(previous.PostContent, current.PostContent),
+		HashtagsAdded:    added,
+		HashtagsRemoved:  removed,
+	}
+	return
+}
+
+// RestorePostRevision copies an old revision back into the post, recorded as a new revision
+func (u *postUsecase) RestorePostRevision(ctx context.Context, postID int64, revision int, userID int64) (status model.PostStatus, err error) {
+	post, err := u.getOwnedPost(ctx, postID, userID, false)
+	if err != nil {
+		return "", err
+	}
+
+	old, err := u.postRepository.GetPostRevision(ctx, postID, revision)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post revision from database")
+		return "", err
+	}
+	if old.ID == 0 {
+		return "", model.ErrRevisionNotFound
+	}
+
+	return u.editPost(ctx, post, userID, old.PostTitle, old.PostContent, old.PostHashtags)
+}
+
+func newPostRevision(postID, editorID int64, title, content string, hashtags []string, createdAt time.Time) model.PostRevision {
+	if hashtags == nil {
+		hashtags = []string{}
+	}
+
+	return model.PostRevision{
+		PostID:       postID,
+		PostTitle:    title,
+		PostContent:  content,
+		PostHashtags: hashtags,
+		EditorID:     &editorID,
+		CreatedAt:    createdAt,
+		CreatedBy:    strconv.FormatInt(editorID, 10),
+	}
+}
+
+func diffHashtags(previous, current []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+
+	inPrevious := make(map[string]bool, len(previous))
+	for _, tag := range previous {
+		inPrevious[tag] = true
+	}
+	inCurrent := make(map[string]bool, len(current))
+	for _, tag := range current {
+		inCurrent[tag] = true
+		if !inPrevious[tag] {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range previous {
+		if !inCurrent[tag] {
+			removed = append(removed, tag)
+		}
+	}
+	return
+}
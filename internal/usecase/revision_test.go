@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/diff"
+)
+
+func TestGetPostRevisions(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+
+	t.Run("Success GetPostRevisions", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		revisions := []model.PostRevisionSummary{{Revision: 2}, {Revision: 1}}
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(3), int64(1)).Return(false, nil)
+		mockRepo.On("GetPostRevisions", ctx, postID).Return(revisions, nil)
+
+		resp, err := usecase.GetPostRevisions(ctx, postID, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, revisions, resp.Data)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostRevisions - Blocked By Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(3), int64(1)).Return(true, nil)
+
+		_, err := usecase.GetPostRevisions(ctx, postID, 1)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostRevisions - Draft Of Another User", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2, Status: model.PostStatusDraft}, nil)
+
+		_, err := usecase.GetPostRevisions(ctx, postID, 1)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostRevisions - Post Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{}, nil)
+
+		_, err := usecase.GetPostRevisions(ctx, postID, 1)
+
+		assert.ErrorIs(t, err, model.ErrPostNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetPostRevision(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+
+	t.Run("Success GetPostRevision", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		previous := model.PostRevision{ID: 1, PostID: postID, Revision: 1, PostTitle: "Title", PostContent: "a\nb", PostHashtags: []string{"go", "old"}}
+		current := model.PostRevision{ID: 2, PostID: postID, Revision: 2, PostTitle: "Title", PostContent: "a\nc", PostHashtags: []string{"go", "new"}}
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(3), int64(1)).Return(false, nil)
+		mockRepo.On("GetPostRevision", ctx, postID, 2).Return(current, nil)
+		mockRepo.On("GetPreviousPostRevision", ctx, postID, 2).Return(previous, nil)
+
+		resp, err := usecase.GetPostRevision(ctx, postID, 2, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.PreviousRevision)
+		assert.Equal(t, []diff.Line{{Op: diff.Equal, Text: "Title"}}, resp.TitleDiff)
+		assert.Equal(t, []diff.Line{
+			{Op: diff.Equal, Text: "a"},
+			{Op: diff.Delete, Text: "b"},
+			{Op: diff.Insert, Text: "c"},
+		}, resp.ContentDiff)
+		assert.Equal(t, []string{"new"}, resp.HashtagsAdded)
+		assert.Equal(t, []string{"old"}, resp.HashtagsRemoved)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostRevision - Revision Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 3, Status: model.PostStatusPublished}, nil)
+		mockRepo.On("IsBlocked", ctx, int64(3), int64(1)).Return(false, nil)
+		mockRepo.On("GetPostRevision", ctx, postID, 5).Return(model.PostRevision{}, nil)
+
+		_, err := usecase.GetPostRevision(ctx, postID, 5, 1)
+
+		assert.ErrorIs(t, err, model.ErrRevisionNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestorePostRevision(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(1)
+
+	t.Run("Success RestorePostRevision", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		old := model.PostRevision{ID: 1, PostID: postID, Revision: 1, PostTitle: "Old Title", PostContent: "Old", PostHashtags: []string{"go"}}
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, PostTitle: "New Title"}, nil)
+		mockRepo.On("GetPostRevision", ctx, postID, 1).Return(old, nil)
+		mockRepo.On("CountPostRevisions", ctx, postID).Return(2, nil)
+		mockRepo.On("UpdatePost", ctx, mock.MatchedBy(func(post model.Post) bool {
+			return post.PostTitle == "Old Title" && assert.ObjectsAreEqual([]string{"go"}, post.PostHashtags)
+		}), mock.MatchedBy(func(revisions []model.PostRevision) bool {
+			return len(revisions) == 1 && revisions[0].PostTitle == "Old Title"
+		})).Return(nil)
+
+		_, err := usecase.RestorePostRevision(ctx, postID, 1, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RestorePostRevision - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2}, nil)
+
+		_, err := usecase.RestorePostRevision(ctx, postID, 1, userID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/search"
+)
+
+const (
+	maxSearchQueryLength = 200
+	defaultSearchSize    = 10
+	maxSearchSize        = 50
+	searchSnippetLength  = 200
+)
+
+func (u *searchUsecase) Search(ctx context.Context, query string, includeComments bool, viewerID int64, pageSize, pageIndex int) (resp model.SearchResponse, err error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return resp, fmt.Errorf("%w: search query is longer than %d characters", model.ErrInvalidInput, maxSearchQueryLength)
+	}
+
+	terms := search.Tokenize(query)
+	if len(terms) == 0 {
+		return resp, fmt.Errorf("%w: search query is empty", model.ErrInvalidInput)
+	}
+
+	if pageSize <= 0 {
+		pageSize = defaultSearchSize
+	}
+	if pageSize > maxSearchSize {
+		pageSize = maxSearchSize
+	}
+	if pageIndex <= 0 {
+		pageIndex = 1
+	}
+
+	limit := pageSize
+	offset := pageSize * (pageIndex - 1)
+	// one extra hit tells whether another page follows
+	hits, err := u.searchRepository.Search(ctx, model.SearchQuery{
+		Query:           query,
+		IncludeComments: includeComments,
+		ViewerID:        viewerID,
+		Limit:           limit + 1,
+		Offset:          offset,
+	})
+	if err != nil {
+		return
+	}
+
+	hasMore := len(hits) > limit
+	if hasMore {
+		hits = hits[:limit]
+	}
+
+	data := make([]model.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		data = append(data, model.SearchResult{
+			Type:      hit.Type,
+			PostID:    hit.PostID,
+			CommentID: hit.CommentID,
+			PostTitle: search.Highlight(hit.PostTitle, terms),
+			Snippet:   search.Snippet(hit.Content, terms, searchSnippetLength),
+			Score:     hit.Score,
+		})
+	}
+
+	resp.Data = data
+	resp.Pagination = model.Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: hasMore,
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/internal/repository/memory"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	viewerID := int64(1)
+
+	index := memory.NewSearchIndex()
+	index.IndexPost(model.Post{ID: 1, UserID: 2, PostTitle: "Concurrency in Go", PostContent: strings.Repeat("intro ", 60) + "channels and goroutines in go " + strings.Repeat("outro ", 60), Status: model.PostStatusPublished})
+	index.IndexPost(model.Post{ID: 2, UserID: 2, PostTitle: "Gardening", PostContent: "Tomatoes <3", Status: model.PostStatusPublished})
+	index.IndexComment(model.Comment{ID: 3, PostID: 2, UserID: 3, CommentContent: "Plant them like goroutines & go", Status: model.CommentStatusApproved})
+
+	t.Run("Success Search - Highlights Title And Snippet", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		resp, err := usecase.Search(ctx, "  GO ", false, viewerID, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, "Concurrency in <mark>Go</mark>", resp.Data[0].PostTitle)
+		assert.Contains(t, resp.Data[0].Snippet, "goroutines in <mark>go</mark>")
+		assert.True(t, strings.HasPrefix(resp.Data[0].Snippet, "…"))
+		assert.Equal(t, defaultSearchSize, resp.Pagination.Limit)
+	})
+
+	t.Run("Success Search - Include Comments", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		resp, err := usecase.Search(ctx, "goroutines", true, viewerID, 10, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 2)
+		assert.Equal(t, model.SearchResultTypeComment, resp.Data[0].Type)
+		assert.Equal(t, int64(3), resp.Data[0].CommentID)
+		assert.Equal(t, "Gardening", resp.Data[0].PostTitle)
+		assert.Equal(t, "Plant them like <mark>goroutines</mark> &amp; go", resp.Data[0].Snippet)
+		assert.Equal(t, model.SearchResultTypePost, resp.Data[1].Type)
+	})
+
+	t.Run("Success Search - Has More", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		resp, err := usecase.Search(ctx, "goroutines", true, viewerID, 1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, model.Pagination{Limit: 1, Offset: 0, HasMore: true}, resp.Pagination)
+
+		resp, err = usecase.Search(ctx, "goroutines", true, viewerID, 1, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, model.Pagination{Limit: 1, Offset: 1, HasMore: false}, resp.Pagination)
+	})
+
+	t.Run("Success Search - Caps Page Size", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		resp, err := usecase.Search(ctx, "go", false, viewerID, 1000, 2)
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.Equal(t, model.Pagination{Limit: maxSearchSize, Offset: maxSearchSize}, resp.Pagination)
+	})
+
+	t.Run("Fail Search - Empty Query", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		_, err := usecase.Search(ctx, " ?! ", false, viewerID, 10, 1)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+
+	t.Run("Fail Search - Query Too Long", func(t *testing.T) {
+		usecase := &searchUsecase{searchRepository: index}
+
+		_, err := usecase.Search(ctx, strings.Repeat("a", maxSearchQueryLength+1), false, viewerID, 10, 1)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+const maxHashtagLength = 100
+
+func (u *postUsecase) GetTags(ctx context.Context, pageSize, pageIndex int) (tags model.GetTagsResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	tags, err = u.postRepository.GetTags(ctx, limit, offset)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func (u *postUsecase) GetPostsByTag(ctx context.Context, tag string, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error) {
+	name := normalizeHashtag(tag)
+	if name == "" {
+		return posts, fmt.Errorf("%w: tag name is empty", model.ErrInvalidInput)
+	}
+
+	limit, offset := pageOffset(pageSize, pageIndex)
+	page := model.PageQuery{
+		Limit:  limit,
+		Offset: offset,
+	}
+	posts, err = u.postRepository.GetAllPost(ctx, viewerID, model.PostFilter{Hashtag: name, Sort: model.PostSortUpdated}, page)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// normalizeHashtags normalizes every hashtag, dropping empty values and duplicates
+func normalizeHashtags(hashtags []string) ([]string, error) {
+	normalized := []string{}
+	seen := make(map[string]bool, len(hashtags))
+	for _, hashtag := range hashtags {
+		name := normalizeHashtag(hashtag)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		if utf8.RuneCountInString(name) > maxHashtagLength {
+			return nil, fmt.Errorf("%w: hashtag %q is longer than %d characters", model.ErrInvalidInput, name, maxHashtagLength)
+		}
+
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
+
+// normalizeHashtag lowercases the hashtag, strips the leading '#' and collapses whitespace
+func normalizeHashtag(hashtag string) string {
+	name := strings.TrimLeft(strings.TrimSpace(hashtag), "#")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
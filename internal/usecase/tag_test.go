@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetTags(t *testing.T) {
+	ctx := context.Background()
+	expected := model.GetTagsResponse{Data: []model.Tag{{Name: "golang", PostCount: 3}}}
+
+	t.Run("Success GetTags", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetTags", ctx, 10, 10).Return(expected, nil)
+
+		tags, err := usecase.GetTags(ctx, 10, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tags)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success GetTags - Default Page", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetTags", ctx, defaultPageSize, 0).Return(expected, nil)
+
+		tags, err := usecase.GetTags(ctx, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tags)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetPostsByTag(t *testing.T) {
+	ctx := context.Background()
+	viewerID := int64(1)
+
+	t.Run("Success GetPostsByTag - Normalized Name", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		expected := model.GetAllPostResponse{Data: []model.PostDetail{{ID: 1}}}
+		mockRepo.On("GetAllPost", ctx, viewerID, model.PostFilter{Hashtag: "golang", Sort: model.PostSortUpdated}, model.PageQuery{Limit: 10}).Return(expected, nil)
+
+		posts, err := usecase.GetPostsByTag(ctx, "#GoLang", viewerID, 10, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, posts)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetPostsByTag - Empty Name", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		_, err := usecase.GetPostsByTag(ctx, " # ", viewerID, 10, 1)
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestNormalizeHashtags(t *testing.T) {
+	t.Run("Success NormalizeHashtags", func(t *testing.T) {
+		hashtags, err := normalizeHashtags([]string{"#Go", "go", "  Machine\tLearning ", ""})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"go", "machine learning"}, hashtags)
+	})
+
+	t.Run("Fail NormalizeHashtags - Too Long", func(t *testing.T) {
+		_, err := normalizeHashtags([]string{strings.Repeat("a", maxHashtagLength+1)})
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func (u *postUsecase) GetTrash(ctx context.Context, userID int64) (trash model.TrashResponse, err error) {
+	posts, err := u.postRepository.GetTrashedPosts(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get trashed posts from database")
+		return
+	}
+
+	comments, err := u.postRepository.GetTrashedComments(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get trashed comments from database")
+		return
+	}
+
+	trash = model.TrashResponse{
+		Posts:    posts,
+		Comments: comments,
+	}
+	return
+}
+
+func (u *postUsecase) RestorePost(ctx context.Context, postID, userID int64) (err error) {
+	post, err := u.getOwnedPost(ctx, postID, userID, true)
+	if err != nil {
+		return err
+	}
+
+	post.UpdatedAt = time.Now()
+	post.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.RestorePost(ctx, post)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *postUsecase) RestoreComment(ctx context.Context, commentID, userID int64) (err error) {
+	comment, err := u.postRepository.GetComment(ctx, commentID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get comment from database")
+		return err
+	}
+
+	if comment.ID == 0 {
+		return model.ErrCommentNotFound
+	}
+
+	if comment.UserID != userID {
+		return model.ErrForbidden
+	}
+
+	if comment.DeletedAt == nil {
+		return model.ErrNotInTrash
+	}
+
+	// a comment removed by the post author stays removed
+	if comment.DeletedBy != nil && *comment.DeletedBy != comment.UserID {
+		return model.ErrForbidden
+	}
+
+	comment.UpdatedAt = time.Now()
+	comment.UpdatedBy = strconv.FormatInt(userID, 10)
+
+	err = u.postRepository.RestoreComment(ctx, comment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes every post and comment trashed before the given time
+func (u *postUsecase) PurgeTrash(ctx context.Context, before time.Time) (err error) {
+	purged, err := u.postRepository.PurgeTrash(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		log.Info().Int64("purged", purged).Msg("purged trashed posts and comments")
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestGetTrash(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	t.Run("Success GetTrash", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		posts := []model.TrashedPost{{ID: 1, PostTitle: "Title"}}
+		comments := []model.TrashedComment{{ID: 2, PostID: 1, CommentContent: "Comment"}}
+		mockRepo.On("GetTrashedPosts", ctx, userID).Return(posts, nil)
+		mockRepo.On("GetTrashedComments", ctx, userID).Return(comments, nil)
+
+		trash, err := usecase.GetTrash(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, posts, trash.Posts)
+		assert.Equal(t, comments, trash.Comments)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail GetTrash - Repository Error", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetTrashedPosts", ctx, userID).Return([]model.TrashedPost{}, assert.AnError)
+
+		_, err := usecase.GetTrash(ctx, userID)
+
+		assert.Equal(t, assert.AnError, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestorePost(t *testing.T) {
+	ctx := context.Background()
+	postID := int64(1)
+	userID := int64(1)
+	deletedAt := time.Now()
+
+	t.Run("Success RestorePost", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID, DeletedAt: &deletedAt}, nil)
+		mockRepo.On("RestorePost", ctx, mock.AnythingOfType("model.Post")).Return(nil)
+
+		err := usecase.RestorePost(ctx, postID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RestorePost - Not In Trash", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: userID}, nil)
+
+		err := usecase.RestorePost(ctx, postID, userID)
+
+		assert.ErrorIs(t, err, model.ErrNotInTrash)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RestorePost - Not Owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetPost", ctx, postID).Return(model.Post{ID: postID, UserID: 2, DeletedAt: &deletedAt}, nil)
+
+		err := usecase.RestorePost(ctx, postID, userID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreComment(t *testing.T) {
+	ctx := context.Background()
+	commentID := int64(1)
+	userID := int64(1)
+	deletedAt := time.Now()
+
+	t.Run("Success RestoreComment", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetComment", ctx, commentID).Return(model.Comment{ID: commentID, UserID: userID, DeletedAt: &deletedAt}, nil)
+		mockRepo.On("RestoreComment", ctx, mock.AnythingOfType("model.Comment")).Return(nil)
+
+		err := usecase.RestoreComment(ctx, commentID, userID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RestoreComment - Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		mockRepo.On("GetComment", ctx, commentID).Return(model.Comment{}, nil)
+
+		err := usecase.RestoreComment(ctx, commentID, userID)
+
+		assert.ErrorIs(t, err, model.ErrCommentNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RestoreComment - Deleted By Post Author", func(t *testing.T) {
+		mockRepo := new(mocks.MockPostRepository)
+		usecase := &postUsecase{postRepository: mockRepo}
+
+		postAuthorID := int64(2)
+		mockRepo.On("GetComment", ctx, commentID).Return(model.Comment{ID: commentID, UserID: userID, DeletedAt: &deletedAt, DeletedBy: &postAuthorID}, nil)
+
+		err := usecase.RestoreComment(ctx, commentID, userID)
+
+		assert.ErrorIs(t, err, model.ErrForbidden)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeTrash(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	mockRepo := new(mocks.MockPostRepository)
+	usecase := &postUsecase{postRepository: mockRepo}
+
+	mockRepo.On("PurgeTrash", ctx, before).Return(int64(2), nil)
+
+	err := usecase.PurgeTrash(ctx, before)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
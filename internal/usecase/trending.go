@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/trending"
+)
+
+// RefreshTrending scores the engagement within the window and replaces the
+// cached rankings with the best scored posts and hashtags
+func (u *trendingUsecase) RefreshTrending(ctx context.Context, now time.Time) (err error) {
+	since := now.Add(-u.window)
+	engagements, err := u.trendingRepository.GetEngagements(ctx, since)
+	if err != nil {
+		log.Error().Err(err).Msg("error get engagements from database")
+		return
+	}
+
+	scores := trending.Score(engagements, u.weights, u.halfLife, now)
+	postIDs := make([]int64, 0, len(scores))
+	for postID := range scores {
+		postIDs = append(postIDs, postID)
+	}
+
+	postTags, err := u.trendingRepository.GetPostTags(ctx, postIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post tags from database")
+		return
+	}
+
+	posts := trending.TopPosts(scores, u.size)
+	tags := trending.TopTags(scores, postTags, u.size)
+	if err = u.trendingRepository.ReplaceTrending(ctx, posts, tags, now); err != nil {
+		log.Error().Err(err).Msg("error replace trending in database")
+		return
+	}
+
+	pruned, err := u.trendingRepository.PruneViewBuckets(ctx, since)
+	if err != nil {
+		log.Error().Err(err).Msg("error prune view buckets from database")
+		return
+	}
+
+	log.Info().Int("posts", len(posts)).Int("tags", len(tags)).Int64("pruned_view_buckets", pruned).Msg("trending refreshed")
+	return nil
+}
+
+func (u *trendingUsecase) GetTrendingPosts(ctx context.Context, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	posts, err = u.trendingRepository.GetTrendingPosts(ctx, viewerID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get trending posts from database")
+		return
+	}
+	return
+}
+
+func (u *trendingUsecase) GetTrendingTags(ctx context.Context, pageSize, pageIndex int) (tags model.GetTrendingTagsResponse, err error) {
+	limit, offset := pageOffset(pageSize, pageIndex)
+	tags, err = u.trendingRepository.GetTrendingTags(ctx, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get trending tags from database")
+		return
+	}
+	return
+}
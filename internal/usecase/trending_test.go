@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/trending"
+)
+
+func newTestTrendingUsecase(repo *mocks.MockTrendingRepository) *trendingUsecase {
+	return &trendingUsecase{
+		trendingRepository: repo,
+		weights:            trending.Weights{model.EngagementLike: 1, model.EngagementComment: 2},
+		halfLife:           24 * time.Hour,
+		window:             72 * time.Hour,
+		size:               10,
+	}
+}
+
+func TestRefreshTrending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	since := now.Add(-72 * time.Hour)
+
+	t.Run("Success RefreshTrending", func(t *testing.T) {
+		mockTrendingRepo := new(mocks.MockTrendingRepository)
+		usecase := newTestTrendingUsecase(mockTrendingRepo)
+
+		mockTrendingRepo.On("GetEngagements", ctx, since).Return([]model.Engagement{
+			{PostID: 1, Kind: model.EngagementLike, Hour: now, Count: 3},
+			{PostID: 2, Kind: model.EngagementComment, Hour: now.Add(-24 * time.Hour), Count: 1},
+		}, nil)
+		mockTrendingRepo.On("GetPostTags", ctx, mock.MatchedBy(func(postIDs []int64) bool {
+			return assert.ElementsMatch(t, []int64{1, 2}, postIDs)
+		})).Return(map[int64][]string{1: {"go"}, 2: {"go", "sql"}}, nil)
+		mockTrendingRepo.On("ReplaceTrending", ctx,
+			[]model.TrendingPost{{PostID: 1, Score: 3}, {PostID: 2, Score: 1}},
+			[]model.TrendingTag{{Name: "go", Score: 4, PostCount: 2}, {Name: "sql", Score: 1, PostCount: 1}},
+			now,
+		).Return(nil)
+		mockTrendingRepo.On("PruneViewBuckets", ctx, since).Return(int64(0), nil)
+
+		err := usecase.RefreshTrending(ctx, now)
+
+		assert.NoError(t, err)
+		mockTrendingRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail RefreshTrending - Keeps Ranking On Error", func(t *testing.T) {
+		mockTrendingRepo := new(mocks.MockTrendingRepository)
+		usecase := newTestTrendingUsecase(mockTrendingRepo)
+
+		mockTrendingRepo.On("GetEngagements", ctx, since).Return([]model.Engagement{}, assert.AnError)
+
+		err := usecase.RefreshTrending(ctx, now)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		mockTrendingRepo.AssertNotCalled(t, "ReplaceTrending")
+	})
+}
+
+func TestGetTrendingPosts(t *testing.T) {
+	ctx := context.Background()
+	mockTrendingRepo := new(mocks.MockTrendingRepository)
+	usecase := newTestTrendingUsecase(mockTrendingRepo)
+
+	expected := model.GetAllPostResponse{Data: []model.PostDetail{{ID: 1}}}
+	mockTrendingRepo.On("GetTrendingPosts", ctx, int64(2), 5, 5).Return(expected, nil)
+
+	posts, err := usecase.GetTrendingPosts(ctx, 2, 5, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, posts)
+	mockTrendingRepo.AssertExpectations(t)
+}
+
+func TestGetTrendingTags(t *testing.T) {
+	ctx := context.Background()
+	mockTrendingRepo := new(mocks.MockTrendingRepository)
+	usecase := newTestTrendingUsecase(mockTrendingRepo)
+
+	expected := model.GetTrendingTagsResponse{Data: []model.TrendingTag{{Name: "go", Score: 4, PostCount: 2}}}
+	mockTrendingRepo.On("GetTrendingTags", ctx, defaultPageSize, 0).Return(expected, nil)
+
+	tags, err := usecase.GetTrendingTags(ctx, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tags)
+	mockTrendingRepo.AssertExpectations(t)
+}
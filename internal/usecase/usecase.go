@@ -2,41 +2,213 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	repository "github.com/suhriar/blog-mono-api/internal/repository/mysql"
 	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/filter"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/trending"
+	"github.com/suhriar/blog-mono-api/pkg/viewcount"
 )
 
 type UserUsecase interface {
 	SignUp(ctx context.Context, req model.SignUpRequest) (err error)
 	Login(ctx context.Context, req model.LoginRequest) (jwtToken, refreshToken string, err error)
 	ValidateRefreshToken(ctx context.Context, userID int64, request model.RefreshTokenRequest) (jwtToken string, err error)
+	GetMe(ctx context.Context, userID int64) (profile model.UserProfile, err error)
+	UpdateMe(ctx context.Context, userID int64, req model.UpdateProfileRequest) (profile model.UserProfile, err error)
+	GetProfile(ctx context.Context, username string, viewerID int64) (profile model.UserProfile, err error)
+	ChangePassword(ctx context.Context, userID int64, req model.ChangePasswordRequest) (err error)
+	ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) (err error)
+	ResetPassword(ctx context.Context, req model.ResetPasswordRequest) (err error)
+	VerifyEmail(ctx context.Context, token string) (err error)
+	ResendEmailVerification(ctx context.Context, req model.ResendVerificationRequest) (err error)
+	ExportMe(ctx context.Context, userID int64) (export model.UserExport, err error)
+	DeleteMe(ctx context.Context, userID int64, req model.DeleteAccountRequest) (err error)
 }
 
 type userUsecase struct {
-	userRepository repository.UserRepository
+	userRepository       repository.UserRepository
+	mailer               mailer.Mailer
+	passwordResetTTL     time.Duration
+	passwordResetURL     string
+	verificationTTL      time.Duration
+	verificationURL      string
+	verificationInterval time.Duration
+	unverifiedAccess     model.UnverifiedAccess
+	deletionPolicy       model.AccountDeletionPolicy
 }
 
-func NewUserUsecase(userRepository repository.UserRepository) UserUsecase {
+func NewUserUsecase(userRepository repository.UserRepository, mailer mailer.Mailer, passwordResetTTL time.Duration, passwordResetURL string, verificationTTL time.Duration, verificationURL string, verificationInterval time.Duration, unverifiedAccess model.UnverifiedAccess, deletionPolicy model.AccountDeletionPolicy) UserUsecase {
 	return &userUsecase{
-		userRepository: userRepository,
+		userRepository:       userRepository,
+		mailer:               mailer,
+		passwordResetTTL:     passwordResetTTL,
+		passwordResetURL:     passwordResetURL,
+		verificationTTL:      verificationTTL,
+		verificationURL:      verificationURL,
+		verificationInterval: verificationInterval,
+		unverifiedAccess:     unverifiedAccess,
+		deletionPolicy:       deletionPolicy,
 	}
 }
 
 type PostUsecase interface {
-	CreatePost(ctx context.Context, userID int64, req model.CreatePostRequest) (err error)
-	GetPostByID(ctx context.Context, postID int64) (post model.GetPostResponse, err error)
-	GetAllPost(ctx context.Context, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error)
-	CreateComment(ctx context.Context, postID, userID int64, request model.CreateCommentRequest) (err error)
+	CreatePost(ctx context.Context, userID int64, req model.CreatePostRequest) (status model.PostStatus, err error)
+	GetPostByID(ctx context.Context, postID, viewerID int64, viewerIP string, threaded bool) (post model.GetPostResponse, err error)
+	GetAllPost(ctx context.Context, viewerID int64, filter model.PostFilter, pageSize, pageIndex int, cursor string) (posts model.GetAllPostResponse, err error)
+	UpdatePost(ctx context.Context, postID, userID int64, req model.UpdatePostRequest) (status model.PostStatus, err error)
+	DeletePost(ctx context.Context, postID, userID int64) (err error)
+	UpdatePostStatus(ctx context.Context, postID, userID int64, req model.UpdatePostStatusRequest) (err error)
+	UpdateCommentSettings(ctx context.Context, postID, userID int64, req model.UpdateCommentSettingsRequest) (err error)
+	GetPendingComments(ctx context.Context, postID, userID int64) (comments []model.CommentResponse, err error)
+	ApproveComment(ctx context.Context, postID, commentID, userID int64) (err error)
+	RejectComment(ctx context.Context, postID, commentID, userID int64) (err error)
+	GetPendingPosts(ctx context.Context, userID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error)
+	ApprovePost(ctx context.Context, postID, userID int64) (err error)
+	RejectPost(ctx context.Context, postID, userID int64) (err error)
+	PublishScheduledPosts(ctx context.Context, now time.Time) (err error)
+	GetTags(ctx context.Context, pageSize, pageIndex int) (tags model.GetTagsResponse, err error)
+	GetPostsByTag(ctx context.Context, tag string, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error)
+	GetPostRevisions(ctx context.Context, postID, viewerID int64) (revisions model.GetPostRevisionsResponse, err error)
+	GetPostRevision(ctx context.Context, postID int64, revision int, viewerID int64) (resp model.PostRevisionDiffResponse, err error)
+	RestorePostRevision(ctx context.Context, postID int64, revision int, userID int64) (status model.PostStatus, err error)
+	GetTrash(ctx context.Context, userID int64) (trash model.TrashResponse, err error)
+	RestorePost(ctx context.Context, postID, userID int64) (err error)
+	RestoreComment(ctx context.Context, commentID, userID int64) (err error)
+	PurgeTrash(ctx context.Context, before time.Time) (err error)
+	GetComments(ctx context.Context, postID, viewerID int64, sort model.CommentSort, pageSize int, cursor string, threaded bool) (comments model.GetCommentsResponse, err error)
+	GetCommentReplies(ctx context.Context, postID, commentID, viewerID int64, pageSize, pageIndex int, threaded bool) (replies model.GetCommentsResponse, err error)
+	CreateComment(ctx context.Context, postID, userID int64, request model.CreateCommentRequest) (status model.CommentStatus, err error)
+	UpdateComment(ctx context.Context, postID, commentID, userID int64, request model.UpdateCommentRequest) (status model.CommentStatus, err error)
+	DeleteComment(ctx context.Context, postID, commentID, userID int64) (err error)
 	UpsertUserActivity(ctx context.Context, postID, userID int64, request model.UserActivityRequest) (err error)
+	GetPostLikes(ctx context.Context, postID, viewerID int64, pageSize, pageIndex int) (likes model.GetPostLikesResponse, err error)
+	GetLikedPosts(ctx context.Context, userID, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error)
+	GetFeed(ctx context.Context, viewerID int64, pageSize int, cursor string) (posts model.GetAllPostResponse, err error)
 }
 
 type postUsecase struct {
-	postRepository repository.PostRepository
+	postRepository    repository.PostRepository
+	maxCommentDepth   int
+	commentEmbedLimit int
+	commentReplyLimit int
+	contentPolicy     filter.Policy
+	contentTrainer    filter.Trainer
+	reactionTypes     []string
+	viewCounter       *viewcount.Counter
+	moderatorIDs      []int64
 }
 
-func NewPostUsecase(postRepository repository.PostRepository) PostUsecase {
+func NewPostUsecase(postRepository repository.PostRepository, maxCommentDepth, commentEmbedLimit, commentReplyLimit int, contentPolicy filter.Policy, contentTrainer filter.Trainer, reactionTypes []string, viewCounter *viewcount.Counter, moderatorIDs []int64) PostUsecase {
 	return &postUsecase{
-		postRepository: postRepository,
+		postRepository:    postRepository,
+		maxCommentDepth:   maxCommentDepth,
+		commentEmbedLimit: commentEmbedLimit,
+		commentReplyLimit: commentReplyLimit,
+		contentPolicy:     contentPolicy,
+		contentTrainer:    contentTrainer,
+		reactionTypes:     reactionTypes,
+		viewCounter:       viewCounter,
+		moderatorIDs:      moderatorIDs,
+	}
+}
+
+type SearchUsecase interface {
+	Search(ctx context.Context, query string, includeComments bool, viewerID int64, pageSize, pageIndex int) (resp model.SearchResponse, err error)
+}
+
+type searchUsecase struct {
+	searchRepository repository.SearchRepository
+}
+
+func NewSearchUsecase(searchRepository repository.SearchRepository) SearchUsecase {
+	return &searchUsecase{
+		searchRepository: searchRepository,
+	}
+}
+
+type BookmarkUsecase interface {
+	BookmarkPost(ctx context.Context, postID, userID int64, req model.BookmarkRequest) (err error)
+	RemoveBookmark(ctx context.Context, postID, userID int64) (err error)
+	GetBookmarks(ctx context.Context, userID int64, collection *string, pageSize, pageIndex int) (bookmarks model.GetBookmarksResponse, err error)
+	GetBookmarkCollections(ctx context.Context, userID int64) (collections []model.BookmarkCollection, err error)
+}
+
+type bookmarkUsecase struct {
+	bookmarkRepository repository.BookmarkRepository
+	postRepository     repository.PostRepository
+}
+
+func NewBookmarkUsecase(bookmarkRepository repository.BookmarkRepository, postRepository repository.PostRepository) BookmarkUsecase {
+	return &bookmarkUsecase{
+		bookmarkRepository: bookmarkRepository,
+		postRepository:     postRepository,
+	}
+}
+
+type TrendingUsecase interface {
+	RefreshTrending(ctx context.Context, now time.Time) (err error)
+	GetTrendingPosts(ctx context.Context, viewerID int64, pageSize, pageIndex int) (posts model.GetAllPostResponse, err error)
+	GetTrendingTags(ctx context.Context, pageSize, pageIndex int) (tags model.GetTrendingTagsResponse, err error)
+}
+
+type trendingUsecase struct {
+	trendingRepository repository.TrendingRepository
+	weights            trending.Weights
+	halfLife           time.Duration
+	window             time.Duration
+	size               int
+}
+
+func NewTrendingUsecase(trendingRepository repository.TrendingRepository, weights trending.Weights, halfLife, window time.Duration, size int) TrendingUsecase {
+	return &trendingUsecase{
+		trendingRepository: trendingRepository,
+		weights:            weights,
+		halfLife:           halfLife,
+		window:             window,
+		size:               size,
+	}
+}
+
+type FollowUsecase interface {
+	FollowUser(ctx context.Context, followeeID, userID int64) (err error)
+	UnfollowUser(ctx context.Context, followeeID, userID int64) (err error)
+	GetFollowers(ctx context.Context, userID int64, pageSize, pageIndex int) (followers model.GetFollowsResponse, err error)
+	GetFollowing(ctx context.Context, userID int64, pageSize, pageIndex int) (following model.GetFollowsResponse, err error)
+	GetFollowCounts(ctx context.Context, userID, viewerID int64) (counts model.FollowCounts, err error)
+}
+
+type followUsecase struct {
+	followRepository repository.FollowRepository
+	userRepository   repository.UserRepository
+}
+
+func NewFollowUsecase(followRepository repository.FollowRepository, userRepository repository.UserRepository) FollowUsecase {
+	return &followUsecase{
+		followRepository: followRepository,
+		userRepository:   userRepository,
+	}
+}
+
+type BlockUsecase interface {
+	BlockUser(ctx context.Context, blockedID, userID int64) (err error)
+	UnblockUser(ctx context.Context, blockedID, userID int64) (err error)
+	GetBlockedUsers(ctx context.Context, userID int64, pageSize, pageIndex int) (users model.GetRestrictedUsersResponse, err error)
+	MuteUser(ctx context.Context, mutedID, userID int64) (err error)
+	UnmuteUser(ctx context.Context, mutedID, userID int64) (err error)
+	GetMutedUsers(ctx context.Context, userID int64, pageSize, pageIndex int) (users model.GetRestrictedUsersResponse, err error)
+}
+
+type blockUsecase struct {
+	blockRepository repository.BlockRepository
+	userRepository  repository.UserRepository
+}
+
+func NewBlockUsecase(blockRepository repository.BlockRepository, userRepository repository.UserRepository) BlockUsecase {
+	return &blockUsecase{
+		blockRepository: blockRepository,
+		userRepository:  userRepository,
 	}
 }
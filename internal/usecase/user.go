@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -12,7 +14,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// SignUp creates the account and mails a link to verify its email. Failing to
+// send the mail does not fail the sign-up, the link can be sent again.
 func (u *userUsecase) SignUp(ctx context.Context, req model.SignUpRequest) (err error) {
+	req.Email = strings.TrimSpace(req.Email)
+	if !isEmail(req.Email) {
+		return fmt.Errorf("%w: email is invalid", model.ErrInvalidInput)
+	}
+
 	user, err := u.userRepository.GetUser(ctx, req.Email, req.Username, 0)
 	if err != nil {
 		return err
@@ -38,12 +47,15 @@ func (u *userUsecase) SignUp(ctx context.Context, req model.SignUpRequest) (err
 		UpdatedBy: req.Email,
 	}
 
-	_, err = u.userRepository.CreateUser(ctx, user)
+	user.ID, err = u.userRepository.CreateUser(ctx, user)
 	if err != nil {
 		return err
 	}
 
-	return
+	if err = u.sendEmailVerification(ctx, user); err != nil {
+		log.Error().Err(err).Msg("error send email verification")
+	}
+	return nil
 }
 
 func (u *userUsecase) Login(ctx context.Context, req model.LoginRequest) (jwtToken, refreshToken string, err error) {
@@ -61,7 +73,11 @@ func (u *userUsecase) Login(ctx context.Context, req model.LoginRequest) (jwtTok
 		return "", "", errors.New("email or password is invalid")
 	}
 
-	jwtToken, err = utils.GenerateJWT(user.ID, user.Username, user.Email)
+	if user.EmailVerifiedAt == nil && u.unverifiedAccess == model.UnverifiedAccessNone {
+		return "", "", errors.New("email is not verified")
+	}
+
+	jwtToken, err = utils.GenerateJWT(user.ID, user.Username, user.Email, user.EmailVerifiedAt != nil)
 	if err != nil {
 		return "", "", err
 	}
@@ -120,7 +136,7 @@ func (u *userUsecase) ValidateRefreshToken(ctx context.Context, userID int64, re
 		return "", errors.New("user not exist")
 	}
 
-	jwtToken, err = utils.GenerateJWT(user.ID, user.Username, user.Email)
+	jwtToken, err = utils.GenerateJWT(user.ID, user.Username, user.Email, user.EmailVerifiedAt != nil)
 	if err != nil {
 		return "", err
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/suhriar/blog-mono-api/config"
 	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
 	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -24,16 +25,35 @@ func TestSignUp(t *testing.T) {
 
 	t.Run("Success SignUp", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepository)
-		usecase := &userUsecase{userRepository: mockRepo}
+		outbox := mailer.NewMemory()
+		usecase := &userUsecase{userRepository: mockRepo, mailer: outbox, verificationTTL: 24 * time.Hour, verificationURL: "http://blog.test/verify"}
 		mockRepo.On("GetUser", ctx, req.Email, req.Username, int64(0)).Return(model.User{}, nil)
 		mockRepo.On("CreateUser", ctx, mock.AnythingOfType("model.User")).Return(int64(1), nil)
+		mockRepo.On("CreateEmailVerification", ctx, mock.MatchedBy(func(verification model.EmailVerification) bool {
+			return verification.UserID == 1 && verification.TokenHash != ""
+		})).Return(nil)
 
 		err := usecase.SignUp(ctx, req)
 
 		assert.NoError(t, err)
+		assert.Len(t, outbox.Messages(), 1)
+		assert.Equal(t, req.Email, outbox.Messages()[0].To)
+		assert.Contains(t, outbox.Messages()[0].Body, "http://blog.test/verify?token=")
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Fail SignUp - Invalid Email", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		for _, email := range []string{"", "not-an-email", "Alice <alice@example.com>"} {
+			err := usecase.SignUp(ctx, model.SignUpRequest{Email: email, Username: "alice", Password: "securepassword"})
+
+			assert.ErrorIs(t, err, model.ErrInvalidInput, email)
+		}
+		mockRepo.AssertNotCalled(t, "CreateUser")
+	})
+
 	t.Run("Fail SignUp - Username or Email Already Exists", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepository)
 		usecase := &userUsecase{userRepository: mockRepo}
@@ -93,6 +113,20 @@ func TestLogin(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Fail Login - Email Not Verified", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo, unverifiedAccess: model.UnverifiedAccessNone}
+		mockRepo.On("GetUser", ctx, req.Email, "", int64(0)).Return(mockUser, nil)
+
+		jwtToken, refreshToken, err := usecase.Login(ctx, req)
+
+		assert.Error(t, err)
+		assert.Equal(t, "email is not verified", err.Error())
+		assert.Empty(t, jwtToken)
+		assert.Empty(t, refreshToken)
+		mockRepo.AssertNotCalled(t, "InsertRefreshToken")
+	})
+
 	t.Run("Fail Login - Password Incorrect", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepository)
 		usecase := &userUsecase{userRepository: mockRepo}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+// VerifyEmail marks the email of the user the token was sent to as verified,
+// the token is used up
+func (u *userUsecase) VerifyEmail(ctx context.Context, token string) (err error) {
+	if token == "" {
+		return fmt.Errorf("%w: token is required", model.ErrInvalidInput)
+	}
+
+	userID, err := u.userRepository.VerifyEmail(ctx, utils.HashToken(token), time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("error verify email to database")
+		return err
+	}
+	if userID == 0 {
+		return fmt.Errorf("%w: verification token is invalid or expired", model.ErrInvalidInput)
+	}
+	return nil
+}
+
+// ResendEmailVerification mails a new verification link to the unverified user
+// with the email, at most once every verification interval. Like
+// ForgotPassword it succeeds without sending anything otherwise, so the
+// response does not reveal who has an account.
+func (u *userUsecase) ResendEmailVerification(ctx context.Context, req model.ResendVerificationRequest) (err error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return fmt.Errorf("%w: email is required", model.ErrInvalidInput)
+	}
+
+	user, err := u.userRepository.GetUser(ctx, email, "", 0)
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if user.ID == 0 || !strings.EqualFold(user.Email, email) || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	latest, err := u.userRepository.GetLatestEmailVerification(ctx, user.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get email verification from database")
+		return err
+	}
+	if latest.ID != 0 && time.Since(latest.CreatedAt) < u.verificationInterval {
+		log.Info().Int64("user_id", user.ID).Msg("email verification resend throttled")
+		return nil
+	}
+
+	return u.sendEmailVerification(ctx, user)
+}
+
+// sendEmailVerification stores a new verification token for the user and
+// mails them the link to use it
+func (u *userUsecase) sendEmailVerification(ctx context.Context, user model.User) (err error) {
+	token := utils.GenerateToken()
+	if token == "" {
+		return errors.New("failed to generate verification token")
+	}
+
+	now := time.Now()
+	err = u.userRepository.CreateEmailVerification(ctx, model.EmailVerification{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiredAt: now.Add(u.verificationTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error insert email verification to database")
+		return err
+	}
+
+	link, err := withToken(u.verificationURL, token)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email. It expires in %s.\n\n%s\n\nIf you did not sign up, you can ignore this email.\n",
+			user.Username, u.verificationTTL, link),
+	})
+}
+
+// isEmail reports whether the value is a bare email address, without a
+// display name or angle brackets
+func isEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
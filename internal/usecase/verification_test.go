@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suhriar/blog-mono-api/internal/repository/mysql/mocks"
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/mailer"
+	"github.com/suhriar/blog-mono-api/pkg/utils"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success VerifyEmail", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("VerifyEmail", ctx, utils.HashToken("token"), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+
+		err := usecase.VerifyEmail(ctx, "token")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fail VerifyEmail - Invalid Token", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		usecase := &userUsecase{userRepository: mockRepo}
+
+		mockRepo.On("VerifyEmail", ctx, utils.HashToken("token"), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+
+		err := usecase.VerifyEmail(ctx, "token")
+
+		assert.ErrorIs(t, err, model.ErrInvalidInput)
+	})
+}
+
+func TestResendEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: 1, Email: "alice@example.com", Username: "alice"}
+	req := model.ResendVerificationRequest{Email: "alice@example.com"}
+
+	newUsecase := func(mockRepo *mocks.MockUserRepository, outbox *mailer.Memory) *userUsecase {
+		return &userUsecase{userRepository: mockRepo, mailer: outbox, verificationTTL: 24 * time.Hour, verificationURL: "http://blog.test/verify", verificationInterval: 5 * time.Minute}
+	}
+
+	t.Run("Success ResendEmailVerification", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		outbox := mailer.NewMemory()
+		usecase := newUsecase(mockRepo, outbox)
+
+		mockRepo.On("GetUser", ctx, req.Email, "", int64(0)).Return(user, nil)
+		mockRepo.On("GetLatestEmailVerification", ctx, int64(1)).Return(model.EmailVerification{ID: 3, CreatedAt: time.Now().Add(-10 * time.Minute)}, nil)
+		mockRepo.On("CreateEmailVerification", ctx, mock.AnythingOfType("model.EmailVerification")).Return(nil)
+
+		err := usecase.ResendEmailVerification(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Len(t, outbox.Messages(), 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success ResendEmailVerification - Throttled", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		outbox := mailer.NewMemory()
+		usecase := newUsecase(mockRepo, outbox)
+
+		mockRepo.On("GetUser", ctx, req.Email, "", int64(0)).Return(user, nil)
+		mockRepo.On("GetLatestEmailVerification", ctx, int64(1)).Return(model.EmailVerification{ID: 3, CreatedAt: time.Now().Add(-time.Minute)}, nil)
+
+		err := usecase.ResendEmailVerification(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, outbox.Messages())
+		mockRepo.AssertNotCalled(t, "CreateEmailVerification")
+	})
+
+	t.Run("Success ResendEmailVerification - Already Verified", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		outbox := mailer.NewMemory()
+		usecase := newUsecase(mockRepo, outbox)
+
+		verifiedAt := time.Now()
+		verified := user
+		verified.EmailVerifiedAt = &verifiedAt
+		mockRepo.On("GetUser", ctx, req.Email, "", int64(0)).Return(verified, nil)
+
+		err := usecase.ResendEmailVerification(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, outbox.Messages())
+		mockRepo.AssertNotCalled(t, "GetLatestEmailVerification")
+	})
+}
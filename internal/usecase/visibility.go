@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	repository "github.com/suhriar/blog-mono-api/internal/repository/mysql"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// getVisiblePost returns the post when it exists, is not in the trash, is
+// either published or owned by the viewer and its author does not block the
+// viewer
+func (u *postUsecase) getVisiblePost(ctx context.Context, postID, viewerID int64) (post model.Post, err error) {
+	return getVisiblePost(ctx, u.postRepository, postID, viewerID)
+}
+
+func getVisiblePost(ctx context.Context, postRepository repository.PostRepository, postID, viewerID int64) (post model.Post, err error) {
+	post, err = postRepository.GetPost(ctx, postID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get post from database")
+		return
+	}
+
+	if post.ID == 0 || post.DeletedAt != nil {
+		return post, model.ErrPostNotFound
+	}
+
+	if post.Status != model.PostStatusPublished && post.UserID != viewerID {
+		return post, model.ErrPostNotFound
+	}
+
+	if err = checkNotBlocked(ctx, postRepository, post.UserID, viewerID); err != nil {
+		return post, err
+	}
+	return post, nil
+}
+
+// checkNotBlocked hides the posts of the author from the users they block, as
+// if the posts did not exist
+func checkNotBlocked(ctx context.Context, postRepository repository.PostRepository, authorID, userID int64) error {
+	if authorID == userID {
+		return nil
+	}
+
+	blocked, err := postRepository.IsBlocked(ctx, authorID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get block from database")
+		return err
+	}
+	if blocked {
+		return model.ErrPostNotFound
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Run executes job every interval until ctx is cancelled. Errors are logged
+// and do not stop the worker.
+func Run(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info().Str("worker", name).Dur("interval", interval).Msg("worker started")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Str("worker", name).Msg("worker stopped")
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Error().Err(err).Str("worker", name).Msg("worker job failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+DROP INDEX idx_comments_deleted_at ON comments;
+
+DROP INDEX idx_posts_deleted_at ON posts;
+
+ALTER TABLE comments DROP COLUMN deleted_at;
+
+ALTER TABLE posts DROP COLUMN deleted_at;
@@ -0,0 +1,9 @@
+ALTER TABLE posts
+ADD deleted_at TIMESTAMP NULL DEFAULT NULL;
+
+ALTER TABLE comments
+ADD deleted_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE INDEX idx_posts_deleted_at ON posts (deleted_at);
+
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at);
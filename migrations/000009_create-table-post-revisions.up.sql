@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS post_revisions(
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    post_id INT NOT NULL,
+    revision INT NOT NULL,
+    post_title VARCHAR(250) NOT NULL,
+    post_content LONGTEXT NOT NULL,
+    post_hashtags JSON NOT NULL,
+    editor_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by LONGTEXT NOT NULL,
+    CONSTRAINT unique_post_revision UNIQUE (post_id, revision),
+    CONSTRAINT fk_post_id_post_revisions FOREIGN KEY (post_id) REFERENCES posts(id),
+    CONSTRAINT fk_editor_id_post_revisions FOREIGN KEY (editor_id) REFERENCES users(id)
+);
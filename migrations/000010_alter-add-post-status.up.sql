@@ -0,0 +1,9 @@
+ALTER TABLE posts
+ADD status VARCHAR(20) NOT NULL DEFAULT 'published';
+
+ALTER TABLE posts
+ADD publish_at TIMESTAMP NULL DEFAULT NULL;
+
+UPDATE posts SET publish_at = created_at;
+
+CREATE INDEX idx_posts_status_publish_at ON posts (status, publish_at);
@@ -0,0 +1,12 @@
+ALTER TABLE posts
+ADD post_hashtags LONGTEXT NOT NULL;
+
+UPDATE posts p SET p.post_hashtags = COALESCE((
+    SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',')
+    FROM post_tags pt JOIN tags t ON pt.tag_id = t.id
+    WHERE pt.post_id = p.id
+), '');
+
+DROP TABLE IF EXISTS post_tags;
+
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS tags(
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_tag_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS post_tags(
+    post_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    PRIMARY KEY (post_id, tag_id),
+    CONSTRAINT fk_post_id_post_tags FOREIGN KEY (post_id) REFERENCES posts(id),
+    CONSTRAINT fk_tag_id_post_tags FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX idx_post_tags_tag_id ON post_tags (tag_id);
+
+-- backfill tags from the comma separated posts.post_hashtags values
+CREATE TEMPORARY TABLE tmp_post_hashtags
+WITH RECURSIVE split AS (
+    SELECT id AS post_id,
+        SUBSTRING_INDEX(post_hashtags, ',', 1) AS tag,
+        IF(LOCATE(',', post_hashtags) > 0, SUBSTRING(post_hashtags, LOCATE(',', post_hashtags) + 1), NULL) AS rest
+    FROM posts
+    UNION ALL
+    SELECT post_id,
+        SUBSTRING_INDEX(rest, ',', 1),
+        IF(LOCATE(',', rest) > 0, SUBSTRING(rest, LOCATE(',', rest) + 1), NULL)
+    FROM split WHERE rest IS NOT NULL
+)
+SELECT DISTINCT post_id, LEFT(LOWER(TRIM(LEADING '#' FROM TRIM(tag))), 100) AS tag
+FROM split WHERE TRIM(LEADING '#' FROM TRIM(tag)) <> '';
+
+INSERT IGNORE INTO tags (name) SELECT DISTINCT tag FROM tmp_post_hashtags;
+
+INSERT IGNORE INTO post_tags (post_id, tag_id)
+SELECT tph.post_id, t.id FROM tmp_post_hashtags tph JOIN tags t ON t.name = tph.tag;
+
+DROP TEMPORARY TABLE tmp_post_hashtags;
+
+ALTER TABLE posts DROP COLUMN post_hashtags;
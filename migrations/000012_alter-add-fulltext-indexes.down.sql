@@ -0,0 +1,5 @@
+DROP INDEX ft_tags_name ON tags;
+
+DROP INDEX ft_comments_content ON comments;
+
+DROP INDEX ft_posts_title_content ON posts;
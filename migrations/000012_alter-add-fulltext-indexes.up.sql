@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD FULLTEXT INDEX ft_posts_title_content (post_title, post_content);
+
+ALTER TABLE comments ADD FULLTEXT INDEX ft_comments_content (comment_content);
+
+ALTER TABLE tags ADD FULLTEXT INDEX ft_tags_name (name);
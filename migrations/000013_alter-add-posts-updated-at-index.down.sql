@@ -0,0 +1 @@
+DROP INDEX idx_posts_updated_at_id ON posts;
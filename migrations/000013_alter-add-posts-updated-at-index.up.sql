@@ -0,0 +1 @@
+CREATE INDEX idx_posts_updated_at_id ON posts (updated_at, id);
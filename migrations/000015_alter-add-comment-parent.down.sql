@@ -0,0 +1,3 @@
+ALTER TABLE comments DROP FOREIGN KEY fk_parent_comment_id_comments;
+
+ALTER TABLE comments DROP COLUMN parent_comment_id;
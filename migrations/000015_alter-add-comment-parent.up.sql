@@ -0,0 +1,5 @@
+ALTER TABLE comments
+ADD parent_comment_id INT NULL DEFAULT NULL;
+
+ALTER TABLE comments
+ADD CONSTRAINT fk_parent_comment_id_comments FOREIGN KEY (parent_comment_id) REFERENCES comments(id) ON DELETE SET NULL;
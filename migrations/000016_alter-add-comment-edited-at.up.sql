@@ -0,0 +1,2 @@
+ALTER TABLE comments
+ADD edited_at TIMESTAMP NULL DEFAULT NULL;
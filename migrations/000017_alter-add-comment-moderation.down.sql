@@ -0,0 +1,8 @@
+-- the composite index replaced the index MySQL created for the post_id foreign key
+CREATE INDEX fk_post_id_comments ON comments (post_id);
+
+DROP INDEX idx_comments_post_id_status ON comments;
+
+ALTER TABLE comments DROP COLUMN status;
+
+ALTER TABLE posts DROP COLUMN comment_mode;
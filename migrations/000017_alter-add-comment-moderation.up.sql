@@ -0,0 +1,7 @@
+ALTER TABLE posts
+ADD comment_mode VARCHAR(20) NOT NULL DEFAULT 'open';
+
+ALTER TABLE comments
+ADD status VARCHAR(20) NOT NULL DEFAULT 'approved';
+
+CREATE INDEX idx_comments_post_id_status ON comments (post_id, status);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS classifier_tokens;
+
+DROP TABLE IF EXISTS classifier_documents;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS classifier_documents(
+    label VARCHAR(10) PRIMARY KEY,
+    doc_count INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS classifier_tokens(
+    token VARCHAR(64) PRIMARY KEY,
+    spam_count INT NOT NULL DEFAULT 0,
+    ham_count INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO classifier_documents (label, doc_count) VALUES ('spam', 0), ('ham', 0);
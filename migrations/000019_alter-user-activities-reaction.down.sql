@@ -0,0 +1,11 @@
+-- the composite index replaced the index MySQL created for the post_id foreign key
+CREATE INDEX fk_post_id_user_activities ON user_activities (post_id);
+
+DROP INDEX idx_user_activities_post_id_reaction ON user_activities;
+
+ALTER TABLE user_activities
+ADD is_liked BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE user_activities SET is_liked = true WHERE reaction = 'like';
+
+ALTER TABLE user_activities DROP COLUMN reaction;
@@ -0,0 +1,8 @@
+ALTER TABLE user_activities
+ADD reaction VARCHAR(20) NULL DEFAULT NULL;
+
+UPDATE user_activities SET reaction = 'like' WHERE is_liked = true;
+
+ALTER TABLE user_activities DROP COLUMN is_liked;
+
+CREATE INDEX idx_user_activities_post_id_reaction ON user_activities (post_id, reaction);
@@ -0,0 +1,4 @@
+-- the composite index replaced the index MySQL created for the user_id foreign key
+CREATE INDEX fk_user_id_user_activities ON user_activities (user_id);
+
+DROP INDEX idx_user_activities_user_id_reaction ON user_activities;
@@ -0,0 +1 @@
+CREATE INDEX idx_user_activities_user_id_reaction ON user_activities (user_id, reaction);
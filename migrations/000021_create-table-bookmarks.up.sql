@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS bookmarks(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    post_id INT NOT NULL,
+    collection VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_bookmark UNIQUE (user_id, post_id),
+    CONSTRAINT fk_user_id_bookmarks FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT fk_post_id_bookmarks FOREIGN KEY (post_id) REFERENCES posts(id)
+);
+
+CREATE INDEX idx_bookmarks_user_id_collection ON bookmarks (user_id, collection, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE posts
+ADD view_count BIGINT NOT NULL DEFAULT 0;
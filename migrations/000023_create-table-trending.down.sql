@@ -0,0 +1,9 @@
+DROP INDEX idx_comments_created_at ON comments;
+
+DROP INDEX idx_user_activities_updated_at ON user_activities;
+
+DROP TABLE IF EXISTS trending_tags;
+
+DROP TABLE IF EXISTS trending_posts;
+
+DROP TABLE IF EXISTS post_view_buckets;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS post_view_buckets(
+    post_id INT NOT NULL,
+    bucket_start DATETIME NOT NULL,
+    view_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, bucket_start),
+    CONSTRAINT fk_post_id_post_view_buckets FOREIGN KEY (post_id) REFERENCES posts(id)
+);
+
+CREATE INDEX idx_post_view_buckets_bucket_start ON post_view_buckets (bucket_start);
+
+CREATE TABLE IF NOT EXISTS trending_posts(
+    post_id INT PRIMARY KEY,
+    score DOUBLE NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_post_id_trending_posts FOREIGN KEY (post_id) REFERENCES posts(id)
+);
+
+CREATE INDEX idx_trending_posts_score ON trending_posts (score);
+
+CREATE TABLE IF NOT EXISTS trending_tags(
+    name VARCHAR(100) PRIMARY KEY,
+    score DOUBLE NOT NULL,
+    post_count INT NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_trending_tags_score ON trending_tags (score);
+
+CREATE INDEX idx_user_activities_updated_at ON user_activities (updated_at);
+
+CREATE INDEX idx_comments_created_at ON comments (created_at);
@@ -0,0 +1,6 @@
+-- the composite index replaced the index MySQL created for the user_id foreign key
+CREATE INDEX fk_user_id_posts ON posts (user_id);
+
+DROP INDEX idx_posts_user_id_created_at ON posts;
+
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS follows(
+    follower_id BIGINT NOT NULL,
+    followee_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT fk_follower_id_follows FOREIGN KEY (follower_id) REFERENCES users(id),
+    CONSTRAINT fk_followee_id_follows FOREIGN KEY (followee_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_follows_followee_id_created_at ON follows (followee_id, created_at);
+
+CREATE INDEX idx_posts_user_id_created_at ON posts (user_id, created_at);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS blocks(
+    blocker_id BIGINT NOT NULL,
+    blocked_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT fk_blocker_id_blocks FOREIGN KEY (blocker_id) REFERENCES users(id),
+    CONSTRAINT fk_blocked_id_blocks FOREIGN KEY (blocked_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_blocks_blocked_id ON blocks (blocked_id);
+
+CREATE TABLE IF NOT EXISTS mutes(
+    muter_id BIGINT NOT NULL,
+    muted_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (muter_id, muted_id),
+    CONSTRAINT fk_muter_id_mutes FOREIGN KEY (muter_id) REFERENCES users(id),
+    CONSTRAINT fk_muted_id_mutes FOREIGN KEY (muted_id) REFERENCES users(id)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE users
+ADD display_name VARCHAR(100) NOT NULL DEFAULT '',
+ADD bio VARCHAR(500) NOT NULL DEFAULT '',
+ADD avatar_url VARCHAR(500) NOT NULL DEFAULT '',
+ADD website VARCHAR(500) NOT NULL DEFAULT '',
+ADD location VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS password_resets(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_password_resets_token_hash (token_hash),
+    CONSTRAINT fk_user_id_password_resets FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+
+-- accounts created before verification existed stay usable
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE IF NOT EXISTS email_verifications(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expired_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_email_verifications_token_hash (token_hash),
+    INDEX idx_email_verifications_user_id_created_at (user_id, created_at),
+    CONSTRAINT fk_user_id_email_verifications FOREIGN KEY (user_id) REFERENCES users(id)
+);
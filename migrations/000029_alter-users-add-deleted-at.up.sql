@@ -0,0 +1,2 @@
+ALTER TABLE users
+ADD deleted_at TIMESTAMP NULL DEFAULT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE comments
+DROP COLUMN deleted_by;
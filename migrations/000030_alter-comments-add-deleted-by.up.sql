@@ -0,0 +1,5 @@
+ALTER TABLE comments
+ADD deleted_by BIGINT NULL DEFAULT NULL;
+
+UPDATE comments SET deleted_by = CAST(updated_by AS UNSIGNED)
+WHERE deleted_at IS NOT NULL AND updated_by REGEXP '^[0-9]+$';
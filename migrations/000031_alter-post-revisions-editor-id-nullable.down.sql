@@ -0,0 +1,4 @@
+DELETE FROM post_revisions WHERE editor_id IS NULL;
+
+ALTER TABLE post_revisions
+MODIFY editor_id BIGINT NOT NULL;
@@ -0,0 +1,3 @@
+-- revisions of other users' posts outlive the deleted editor
+ALTER TABLE post_revisions
+MODIFY editor_id BIGINT NULL;
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// AccountDeletionPolicy decides what happens to the content of a deleted account
+type AccountDeletionPolicy string
+
+const (
+	// AccountDeletionAnonymize keeps the posts and comments of the account,
+	// attributed to an anonymous placeholder user
+	AccountDeletionAnonymize AccountDeletionPolicy = "anonymize"
+	// AccountDeletionRemove removes the posts and comments of the account along
+	// with everything referencing them
+	AccountDeletionRemove AccountDeletionPolicy = "delete"
+)
+
+// UserExport is every piece of data the blog keeps about a user, including
+// drafts and trashed content
+type UserExport struct {
+	ExportedAt time.Time          `json:"exported_at"`
+	Profile    UserProfile        `json:"profile"`
+	Posts      []Post             `json:"posts"`
+	Comments   []ExportedComment  `json:"comments"`
+	Reactions  []ExportedReaction `json:"reactions"`
+	Bookmarks  []ExportedBookmark `json:"bookmarks"`
+	Following  []FollowUser       `json:"following"`
+	Sessions   []ExportedSession  `json:"sessions"`
+}
+
+type ExportedComment struct {
+	ID              int64         `json:"id"`
+	PostID          int64         `json:"post_id"`
+	ParentCommentID *int64        `json:"parent_comment_id"`
+	CommentContent  string        `json:"comment_content"`
+	Status          CommentStatus `json:"status"`
+	EditedAt        *time.Time    `json:"edited_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+	DeletedAt       *time.Time    `json:"deleted_at,omitempty"`
+}
+
+type ExportedReaction struct {
+	PostID    int64     `json:"post_id"`
+	Reaction  string    `json:"reaction"`
+	ReactedAt time.Time `json:"reacted_at"`
+}
+
+type ExportedBookmark struct {
+	PostID       int64     `json:"post_id"`
+	Collection   string    `json:"collection"`
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// ExportedSession is a refresh token of the user, without the token itself
+type ExportedSession struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// DeleteAccountRequest confirms the deletion with the password of the account
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
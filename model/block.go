@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Block hides the posts of BlockerID from BlockedID, who can no longer comment
+// on or react to them
+type Block struct {
+	BlockerID int64     `json:"blocker_id" db:"blocker_id"`
+	BlockedID int64     `json:"blocked_id" db:"blocked_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Mute hides the posts of MutedID from the listings and feed of MuterID
+type Mute struct {
+	MuterID   int64     `json:"muter_id" db:"muter_id"`
+	MutedID   int64     `json:"muted_id" db:"muted_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RestrictedUser is a user of a blocks or mutes listing
+type RestrictedUser struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetRestrictedUsersResponse struct {
+	Data       []RestrictedUser `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Bookmark saves a post to the reading list of a user. Every post is
+// bookmarked at most once per user, an empty Collection is the default list.
+type Bookmark struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	PostID     int64     `db:"post_id"`
+	Collection string    `db:"collection"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type BookmarkRequest struct {
+	Collection string `json:"collection"`
+}
+
+// BookmarkedPost is a bookmarked post together with when and where it was saved
+type BookmarkedPost struct {
+	Collection   string     `json:"collection"`
+	BookmarkedAt time.Time  `json:"bookmarked_at"`
+	Post         PostDetail `json:"post"`
+}
+
+type GetBookmarksResponse struct {
+	Data       []BookmarkedPost `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+type BookmarkCollection struct {
+	Name          string `json:"name"`
+	BookmarkCount int    `json:"bookmark_count"`
+}
@@ -2,17 +2,54 @@ package model
 
 import "time"
 
+type CommentStatus string
+
+const (
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusPending  CommentStatus = "pending"
+	CommentStatusRejected CommentStatus = "rejected"
+)
+
 type Comment struct {
-	ID             int64     `db:"id"`
-	PostID         int64     `db:"post_id"`
-	UserID         int64     `db:"user_id"`
-	CommentContent string    `db:"comment_content"`
-	CreatedAt      time.Time `db:"created_at"`
-	UpdatedAt      time.Time `db:"updated_at"`
-	CreatedBy      string    `db:"created_by"`
-	UpdatedBy      string    `db:"updated_by"`
+	ID              int64         `db:"id"`
+	PostID          int64         `db:"post_id"`
+	UserID          int64         `db:"user_id"`
+	ParentCommentID *int64        `db:"parent_comment_id"`
+	CommentContent  string        `db:"comment_content"`
+	Status          CommentStatus `db:"status"`
+	EditedAt        *time.Time    `db:"edited_at"`
+	CreatedAt       time.Time     `db:"created_at"`
+	UpdatedAt       time.Time     `db:"updated_at"`
+	CreatedBy       string        `db:"created_by"`
+	UpdatedBy       string        `db:"updated_by"`
+	DeletedAt       *time.Time    `db:"deleted_at"`
+	DeletedBy       *int64        `db:"deleted_by"`
 }
 
 type CreateCommentRequest struct {
+	CommentContent  string `json:"commentContent"`
+	ParentCommentID *int64 `json:"parentCommentId,omitempty"`
+}
+
+type UpdateCommentRequest struct {
 	CommentContent string `json:"commentContent"`
 }
+
+// DeletedCommentPlaceholder replaces the content of a deleted comment that is
+// still shown because it has replies
+const DeletedCommentPlaceholder = "[deleted]"
+
+type CommentSort string
+
+const (
+	CommentSortOldest CommentSort = "oldest"
+	CommentSortNewest CommentSort = "newest"
+	CommentSortTop    CommentSort = "top"
+)
+
+// GetCommentsResponse is a page of top level comments with their replies,
+// Pagination counts top level comments only
+type GetCommentsResponse struct {
+	Data       []CommentResponse `json:"data"`
+	Pagination Pagination        `json:"pagination"`
+}
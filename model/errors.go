@@ -0,0 +1,18 @@
+package model
+
+import "errors"
+
+var (
+	ErrPostNotFound     = errors.New("post not found")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrNotInTrash       = errors.New("item is not in trash")
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrFollowNotFound   = errors.New("not following this user")
+	ErrBlockNotFound    = errors.New("not blocking this user")
+	ErrMuteNotFound     = errors.New("not muting this user")
+	ErrInvalidInput     = errors.New("invalid input")
+	ErrForbidden        = errors.New("you are not allowed to perform this action")
+	ErrContentRejected  = errors.New("content rejected")
+)
@@ -0,0 +1,22 @@
+package model
+
+type ContentKind string
+
+const (
+	ContentKindPost    ContentKind = "post"
+	ContentKindComment ContentKind = "comment"
+)
+
+// ClassifierToken counts the spam and ham documents a token appeared in
+type ClassifierToken struct {
+	Token     string `db:"token"`
+	SpamCount int    `db:"spam_count"`
+	HamCount  int    `db:"ham_count"`
+}
+
+// ClassifierState is everything the spam classifier learned from moderator decisions
+type ClassifierState struct {
+	SpamDocs int
+	HamDocs  int
+	Tokens   []ClassifierToken
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type Follow struct {
+	FollowerID int64     `json:"follower_id" db:"follower_id"`
+	FolloweeID int64     `json:"followee_id" db:"followee_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// FollowUser is a user of a followers or following listing, FollowedAt is when
+// the follow started
+type FollowUser struct {
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type GetFollowsResponse struct {
+	Data       []FollowUser `json:"data"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// FollowCounts counts the followers and followed users of a user, IsFollowing
+// reports whether the viewer follows them
+type FollowCounts struct {
+	FollowerCount  int  `json:"follower_count"`
+	FollowingCount int  `json:"following_count"`
+	IsFollowing    bool `json:"is_following"`
+}
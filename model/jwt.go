@@ -6,5 +6,7 @@ type JwtCustomClaims struct {
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	// EmailVerified is false for tokens issued before the email was verified
+	EmailVerified bool `json:"email_verified"`
 	jwt.RegisteredClaims
 }
@@ -1,54 +1,187 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// PostStatus is the lifecycle state of a post. Pending posts were held by the
+// content filters and stay hidden from other users until a moderator approves them.
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusScheduled PostStatus = "scheduled"
+	PostStatusPublished PostStatus = "published"
+	PostStatusArchived  PostStatus = "archived"
+	PostStatusPending   PostStatus = "pending"
+)
+
+// CommentMode controls who may comment on a post. Comments on posts requiring
+// approval stay pending until the post author approves them.
+type CommentMode string
+
+const (
+	CommentModeOpen     CommentMode = "open"
+	CommentModeClosed   CommentMode = "closed"
+	CommentModeApproval CommentMode = "approval"
+)
 
 type Post struct {
-	ID           int64     `json:"id" db:"id"`
-	UserID       int64     `json:"user_id" db:"user_id"`
-	PostTitle    string    `json:"post_title" db:"post_title"`
-	PostContent  string    `json:"post_content" db:"post_content"`
-	PostHashtags string    `json:"post_hashtags" db:"post_hashtags"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
-	CreatedBy    string    `json:"created_by" db:"created_by"`
-	UpdatedBy    string    `json:"updated_by" db:"updated_by"`
+	ID           int64       `json:"id" db:"id"`
+	UserID       int64       `json:"user_id" db:"user_id"`
+	PostTitle    string      `json:"post_title" db:"post_title"`
+	PostContent  string      `json:"post_content" db:"post_content"`
+	PostHashtags []string    `json:"post_hashtags"`
+	Status       PostStatus  `json:"status" db:"status"`
+	PublishAt    *time.Time  `json:"publish_at" db:"publish_at"`
+	CommentMode  CommentMode `json:"comment_mode" db:"comment_mode"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+	CreatedBy    string      `json:"created_by" db:"created_by"`
+	UpdatedBy    string      `json:"updated_by" db:"updated_by"`
+	DeletedAt    *time.Time  `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 type CreatePostRequest struct {
+	PostTitle    string     `json:"postTitle"`
+	PostContent  string     `json:"postContent"`
+	PostHashtags []string   `json:"postHashtags"`
+	Status       PostStatus `json:"status"`
+	PublishAt    *time.Time `json:"publishAt"`
+}
+
+type UpdatePostRequest struct {
 	PostTitle    string   `json:"postTitle"`
 	PostContent  string   `json:"postContent"`
 	PostHashtags []string `json:"postHashtags"`
 }
 
+type UpdatePostStatusRequest struct {
+	Status    PostStatus `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+type UpdateCommentSettingsRequest struct {
+	CommentMode CommentMode `json:"commentMode"`
+}
+
 type GetAllPostResponse struct {
 	Data       []PostDetail `json:"data"`
 	Pagination Pagination   `json:"pagination"`
 }
 
 type PostDetail struct {
-	ID           int64    `json:"id"`
-	UserID       int64    `json:"user_id"`
-	Username     string   `json:"username"`
-	PostTitle    string   `json:"post_title"`
-	PostContent  string   `json:"post_content"`
-	PostHashtags []string `json:"post_hashtags"`
-	IsLiked      bool     `json:"isLiked"`
+	ID           int64       `json:"id"`
+	UserID       int64       `json:"user_id"`
+	Username     string      `json:"username"`
+	PostTitle    string      `json:"post_title"`
+	PostContent  string      `json:"post_content"`
+	PostHashtags []string    `json:"post_hashtags"`
+	Status       PostStatus  `json:"status"`
+	PublishAt    *time.Time  `json:"publish_at,omitempty"`
+	CommentMode  CommentMode `json:"comment_mode"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	LikeCount    int         `json:"like_count"`
+	CommentCount int         `json:"comment_count"`
+	ViewCount    int64       `json:"view_count"`
+	IsLiked      bool        `json:"is_liked"`
+	IsBookmarked bool        `json:"is_bookmarked"`
 }
 
+// MarshalJSON also sends is_liked under its former isLiked key, which clients
+// written before the rename still read. The isLiked key is deprecated.
+func (p PostDetail) MarshalJSON() ([]byte, error) {
+	type postDetail PostDetail
+	return json.Marshal(struct {
+		postDetail
+		IsLikedLegacy bool `json:"isLiked"`
+	}{postDetail: postDetail(p), IsLikedLegacy: p.IsLiked})
+}
+
+type PostSort string
+
+const (
+	PostSortUpdated       PostSort = "updated"
+	PostSortNewest        PostSort = "newest"
+	PostSortOldest        PostSort = "oldest"
+	PostSortMostLiked     PostSort = "most_liked"
+	PostSortMostCommented PostSort = "most_commented"
+)
+
+// PostFilter narrows down and orders a post listing, zero values do not filter.
+// From and To bound the creation time, To being exclusive. FollowedBy restricts
+// the listing to the authors followed by that user.
+type PostFilter struct {
+	Author     string
+	Hashtag    string
+	From       *time.Time
+	To         *time.Time
+	FollowedBy int64
+	Sort       PostSort
+}
+
+// Pagination describes the returned page. HasMore reports whether more items
+// exist beyond the page in the direction it was fetched; Total, NextCursor and
+// PrevCursor are only set by the endpoints supporting them.
 type Pagination struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Total      int    `json:"total"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Cursor points at the item a keyset page starts after, through the value of
+// the sort key (Time or Count) and the id. Backward cursors page towards the
+// start of the listing.
+type Cursor struct {
+	Sort     string    `json:"s,omitempty"`
+	Time     time.Time `json:"t"`
+	Count    int64     `json:"n,omitempty"`
+	ID       int64     `json:"id"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+// PageQuery selects a page either by offset or, when Cursor is set, by keyset
+type PageQuery struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
 }
 
+// GetPostResponse embeds the first comment threads of the post, MoreComments
+// links to the next page of threads when there are more. Reactions counts every
+// configured reaction type, ViewerReaction is empty when the viewer did not react.
+// ViewCount includes the views not written to the database yet.
 type GetPostResponse struct {
-	PostDetail PostDetail        `json:"post_detail"`
-	LikeCount  int               `json:"like_count"`
-	Comments   []CommentResponse `json:"comments"`
+	PostDetail     PostDetail        `json:"post_detail"`
+	LikeCount      int               `json:"like_count"`
+	ViewCount      int64             `json:"view_count"`
+	Reactions      map[string]int    `json:"reactions"`
+	ViewerReaction string            `json:"viewer_reaction,omitempty"`
+	Comments       []CommentResponse `json:"comments"`
+	MoreComments   string            `json:"more_comments,omitempty"`
 }
 
+// CommentResponse is a comment with its position in the thread. Depth is 0 for
+// top level comments, ReplyCount counts every reply below the comment and
+// Replies is only filled when comments are returned as a tree. HasMoreReplies
+// marks a comment whose replies were only partly embedded. Deleted comments
+// with replies are kept as placeholders without author or content.
 type CommentResponse struct {
-	ID             int64  `json:"id"`
-	UserID         int64  `json:"user_id"`
-	Username       string `json:"username"`
-	CommentContent string `json:"comment_content"`
+	ID              int64             `json:"id"`
+	UserID          int64             `json:"user_id"`
+	Username        string            `json:"username"`
+	CommentContent  string            `json:"comment_content"`
+	CreatedAt       time.Time         `json:"created_at"`
+	EditedAt        *time.Time        `json:"edited_at"`
+	IsDeleted       bool              `json:"is_deleted"`
+	ParentCommentID *int64            `json:"parent_comment_id"`
+	Depth           int               `json:"depth"`
+	ReplyCount      int               `json:"reply_count"`
+	HasMoreReplies  bool              `json:"has_more_replies,omitempty"`
+	Replies         []CommentResponse `json:"replies,omitempty"`
 }
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/suhriar/blog-mono-api/pkg/diff"
+)
+
+type PostRevision struct {
+	ID           int64     `json:"id" db:"id"`
+	PostID       int64     `json:"post_id" db:"post_id"`
+	Revision     int       `json:"revision" db:"revision"`
+	PostTitle    string    `json:"post_title" db:"post_title"`
+	PostContent  string    `json:"post_content" db:"post_content"`
+	PostHashtags []string  `json:"post_hashtags" db:"post_hashtags"`
+	EditorID     *int64    `json:"editor_id" db:"editor_id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	CreatedBy    string    `json:"created_by" db:"created_by"`
+}
+
+// PostRevisionSummary lists a revision in the history of a post, the editor is
+// empty when their account was deleted
+type PostRevisionSummary struct {
+	Revision       int       `json:"revision"`
+	PostTitle      string    `json:"post_title"`
+	EditorID       *int64    `json:"editor_id"`
+	EditorUsername string    `json:"editor_username"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type GetPostRevisionsResponse struct {
+	Data []PostRevisionSummary `json:"data"`
+}
+
+type PostRevisionDiffResponse struct {
+	Revision         PostRevision `json:"revision"`
+	PreviousRevision int          `json:"previous_revision"`
+	TitleDiff        []diff.Line  `json:"title_diff"`
+	ContentDiff      []diff.Line  `json:"content_diff"`
+	HashtagsAdded    []string     `json:"hashtags_added"`
+	HashtagsRemoved  []string     `json:"hashtags_removed"`
+}
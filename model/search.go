@@ -0,0 +1,38 @@
+package model
+
+const (
+	SearchResultTypePost    = "post"
+	SearchResultTypeComment = "comment"
+)
+
+type SearchQuery struct {
+	Query           string
+	IncludeComments bool
+	ViewerID        int64
+	Limit           int
+	Offset          int
+}
+
+// SearchHit is a raw match returned by a SearchRepository, before snippets are built
+type SearchHit struct {
+	Type      string
+	PostID    int64
+	CommentID int64
+	PostTitle string
+	Content   string
+	Score     float64
+}
+
+type SearchResult struct {
+	Type      string  `json:"type"`
+	PostID    int64   `json:"post_id"`
+	CommentID int64   `json:"comment_id,omitempty"`
+	PostTitle string  `json:"post_title"`
+	Snippet   string  `json:"snippet"`
+	Score     float64 `json:"score"`
+}
+
+type SearchResponse struct {
+	Data       []SearchResult `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
@@ -0,0 +1,11 @@
+package model
+
+type Tag struct {
+	Name      string `json:"name"`
+	PostCount int    `json:"post_count"`
+}
+
+type GetTagsResponse struct {
+	Data       []Tag      `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
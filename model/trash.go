@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type TrashResponse struct {
+	Posts    []TrashedPost    `json:"posts"`
+	Comments []TrashedComment `json:"comments"`
+}
+
+type TrashedPost struct {
+	ID        int64     `json:"id"`
+	PostTitle string    `json:"post_title"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type TrashedComment struct {
+	ID             int64     `json:"id"`
+	PostID         int64     `json:"post_id"`
+	CommentContent string    `json:"comment_content"`
+	DeletedAt      time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type EngagementKind string
+
+const (
+	EngagementLike    EngagementKind = "like"
+	EngagementComment EngagementKind = "comment"
+	EngagementView    EngagementKind = "view"
+)
+
+// Engagement counts the interactions of one kind with a post within the hour
+// starting at Hour
+type Engagement struct {
+	PostID int64
+	Kind   EngagementKind
+	Hour   time.Time
+	Count  int64
+}
+
+// TrendingPost is a row of the cached post ranking
+type TrendingPost struct {
+	PostID int64
+	Score  float64
+}
+
+// TrendingTag is a row of the cached tag ranking, PostCount counts the posts
+// with recent engagement carrying the tag
+type TrendingTag struct {
+	Name      string  `json:"name"`
+	Score     float64 `json:"score"`
+	PostCount int     `json:"post_count"`
+}
+
+type GetTrendingTagsResponse struct {
+	Data       []TrendingTag `json:"data"`
+	Pagination Pagination    `json:"pagination"`
+}
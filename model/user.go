@@ -2,17 +2,36 @@ package model
 
 import "time"
 
+// User is an account, Password holds the bcrypt hash and is never serialized
 type User struct {
-	ID        int64     `json:"id" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	Username  string    `json:"username" db:"username"`
-	Password  string    `json:"password" db:"password"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
-	CreatedBy string    `json:"created_by" db:"created_by"`
-	UpdatedBy string    `json:"updated_by" db:"updated_by"`
+	ID              int64      `json:"id" db:"id"`
+	Email           string     `json:"email" db:"email"`
+	Username        string     `json:"username" db:"username"`
+	Password        string     `json:"-" db:"password"`
+	DisplayName     string     `json:"display_name" db:"display_name"`
+	Bio             string     `json:"bio" db:"bio"`
+	AvatarURL       string     `json:"avatar_url" db:"avatar_url"`
+	Website         string     `json:"website" db:"website"`
+	Location        string     `json:"location" db:"location"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedBy       string     `json:"created_by" db:"created_by"`
+	UpdatedBy       string     `json:"updated_by" db:"updated_by"`
 }
 
+// UnverifiedAccess is what users who have not verified their email may do
+type UnverifiedAccess string
+
+const (
+	// UnverifiedAccessFull lets unverified users do anything verified users can
+	UnverifiedAccessFull UnverifiedAccess = "full"
+	// UnverifiedAccessRead lets unverified users log in and read, but not write
+	UnverifiedAccessRead UnverifiedAccess = "read"
+	// UnverifiedAccessNone keeps unverified users from logging in
+	UnverifiedAccessNone UnverifiedAccess = "none"
+)
+
 type RefreshToken struct {
 	ID           int64     `json:"id" db:"id"`
 	UserID       int64     `json:"user_id" db:"user_id"`
@@ -47,3 +66,71 @@ type LoginResponse struct {
 type RefreshResponse struct {
 	AccessToken string `json:"access_token"`
 }
+
+// UserProfile is the public view of a user, Email is only set for the user
+// themselves. PostCount counts the published posts.
+type UserProfile struct {
+	ID             int64     `json:"id"`
+	Username       string    `json:"username"`
+	Email          string    `json:"email,omitempty"`
+	DisplayName    string    `json:"display_name"`
+	Bio            string    `json:"bio"`
+	AvatarURL      string    `json:"avatar_url"`
+	Website        string    `json:"website"`
+	Location       string    `json:"location"`
+	CreatedAt      time.Time `json:"created_at"`
+	PostCount      int       `json:"post_count"`
+	FollowerCount  int       `json:"follower_count"`
+	FollowingCount int       `json:"following_count"`
+	IsFollowing    bool      `json:"is_following"`
+}
+
+// UpdateProfileRequest changes the fields that are set, an empty string
+// clears the field
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"displayName"`
+	Bio         *string `json:"bio"`
+	AvatarURL   *string `json:"avatarUrl"`
+	Website     *string `json:"website"`
+	Location    *string `json:"location"`
+}
+
+// PasswordReset is a single use password reset token, only the SHA-256 hash
+// of the token sent to the user is stored
+type PasswordReset struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiredAt time.Time  `json:"expired_at" db:"expired_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+// EmailVerification is a single use email verification token, only the
+// SHA-256 hash of the token sent to the user is stored
+type EmailVerification struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiredAt time.Time  `json:"expired_at" db:"expired_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
@@ -2,17 +2,39 @@ package model
 
 import "time"
 
+// ReactionLike is the reaction behind the like count and is always accepted,
+// whatever reaction types are configured
+const ReactionLike = "like"
+
+// UserActivity holds the reaction of a user to a post, an empty Reaction means
+// the user withdrew it
 type UserActivity struct {
 	ID        int64     `db:"id"`
 	PostID    int64     `db:"post_id"`
 	UserID    int64     `db:"user_id"`
-	IsLiked   bool      `db:"is_liked"`
+	Reaction  string    `db:"reaction"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	CreatedBy string    `db:"created_by"`
 	UpdatedBy string    `db:"updated_by"`
 }
 
+// UserActivityRequest sets the reaction of the user. Clients only sending
+// is_liked are mapped to the like reaction, an empty reaction with is_liked
+// false withdraws the current reaction.
 type UserActivityRequest struct {
-	IsLiked bool `json:"is_liked"`
+	IsLiked  bool   `json:"is_liked"`
+	Reaction string `json:"reaction"`
+}
+
+// PostLike is a user who liked a post
+type PostLike struct {
+	UserID   int64     `json:"user_id"`
+	Username string    `json:"username"`
+	LikedAt  time.Time `json:"liked_at"`
+}
+
+type GetPostLikesResponse struct {
+	Data       []PostLike `json:"data"`
+	Pagination Pagination `json:"pagination"`
 }
@@ -0,0 +1,111 @@
+package diff
+
+import "strings"
+
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+// maxTableCells caps the size of the LCS table, changed blocks needing a larger
+// table are diffed as the old lines deleted and the new lines inserted
+const maxTableCells = 1 << 20
+
+type Line struct {
+	Op   Operation `json:"op"`
+	Text string    `json:"text"`
+}
+
+// Lines returns the line-level diff that turns a into b, based on the
+// longest common subsequence of both texts. Past maxTableCells the changed
+// lines are reported as replaced as a whole.
+func Lines(a, b string) []Line {
+	return diff(splitLines(a), splitLines(b))
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func diff(a, b []string) []Line {
+	lines := []Line{}
+
+	// common prefix and suffix do not need the LCS table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, text := range a[:prefix] {
+		lines = append(lines, Line{Op: Equal, Text: text})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	if (len(midA)+1)*(len(midB)+1) > maxTableCells {
+		lines = replaced(lines, midA, midB)
+	} else {
+		lines = lcsDiff(lines, midA, midB)
+	}
+
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, Line{Op: Equal, Text: text})
+	}
+	return lines
+}
+
+// lcsDiff appends the diff of a and b walked along their longest common subsequence
+func lcsDiff(lines []Line, a, b []string) []Line {
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Op: Equal, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: Delete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: Insert, Text: b[j]})
+			j++
+		}
+	}
+	return replaced(lines, a[i:], b[j:])
+}
+
+// replaced appends every line of a as deleted followed by every line of b as inserted
+func replaced(lines []Line, a, b []string) []Line {
+	for _, text := range a {
+		lines = append(lines, Line{Op: Delete, Text: text})
+	}
+	for _, text := range b {
+		lines = append(lines, Line{Op: Insert, Text: text})
+	}
+	return lines
+}
@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	t.Run("Identical", func(t *testing.T) {
+		lines := Lines("a\nb", "a\nb")
+		assert.Equal(t, []Line{{Op: Equal, Text: "a"}, {Op: Equal, Text: "b"}}, lines)
+	})
+
+	t.Run("Insert And Delete", func(t *testing.T) {
+		lines := Lines("a\nb\nc\nd", "a\nx\nc\nd\ne")
+		assert.Equal(t, []Line{
+			{Op: Equal, Text: "a"},
+			{Op: Delete, Text: "b"},
+			{Op: Insert, Text: "x"},
+			{Op: Equal, Text: "c"},
+			{Op: Equal, Text: "d"},
+			{Op: Insert, Text: "e"},
+		}, lines)
+	})
+
+	t.Run("From Empty", func(t *testing.T) {
+		lines := Lines("", "a\nb")
+		assert.Equal(t, []Line{{Op: Insert, Text: "a"}, {Op: Insert, Text: "b"}}, lines)
+	})
+
+	t.Run("To Empty", func(t *testing.T) {
+		lines := Lines("a", "")
+		assert.Equal(t, []Line{{Op: Delete, Text: "a"}}, lines)
+	})
+
+	t.Run("Too Large For The Table", func(t *testing.T) {
+		a := strings.Repeat("a\n", 1100) + "end"
+		b := strings.Repeat("b\n", 1100) + "end"
+
+		lines := Lines(a, b)
+
+		assert.Len(t, lines, 2201)
+		assert.Equal(t, Line{Op: Delete, Text: "a"}, lines[0])
+		assert.Equal(t, Line{Op: Insert, Text: "b"}, lines[1100])
+		assert.Equal(t, Line{Op: Equal, Text: "end"}, lines[2200])
+	})
+}
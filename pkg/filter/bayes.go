@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/suhriar/blog-mono-api/model"
+	"github.com/suhriar/blog-mono-api/pkg/search"
+)
+
+const (
+	// minTrainingDocs is the number of documents each class needs before the
+	// classifier scores anything
+	minTrainingDocs = 5
+	// maxTokenLength keeps garbage tokens out of the stored vocabulary
+	maxTokenLength = 64
+)
+
+// ClassifierStore persists what the classifier learns so it survives restarts
+type ClassifierStore interface {
+	GetClassifierState(ctx context.Context) (state model.ClassifierState, err error)
+	AddClassifierSample(ctx context.Context, tokens []string, spam bool) (err error)
+}
+
+// Trainer learns from moderator decisions on content
+type Trainer interface {
+	Train(ctx context.Context, text string, spam bool) error
+}
+
+type tokenCount struct {
+	spam, ham int
+}
+
+// Bayes is a naive Bayes spam classifier trained from moderator decisions.
+// It scores content with the probability of it being spam.
+type Bayes struct {
+	store ClassifierStore
+
+	mu       sync.RWMutex
+	spamDocs int
+	hamDocs  int
+	tokens   map[string]tokenCount
+}
+
+func NewBayes(store ClassifierStore) *Bayes {
+	return &Bayes{store: store, tokens: map[string]tokenCount{}}
+}
+
+// Load replaces the learned counts with the stored ones
+func (b *Bayes) Load(ctx context.Context) error {
+	state, err := b.store.GetClassifierState(ctx)
+	if err != nil {
+		return err
+	}
+
+	tokens := make(map[string]tokenCount, len(state.Tokens))
+	for _, token := range state.Tokens {
+		tokens[token.Token] = tokenCount{spam: token.SpamCount, ham: token.HamCount}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spamDocs, b.hamDocs, b.tokens = state.SpamDocs, state.HamDocs, tokens
+	return nil
+}
+
+// Train learns from a moderator deciding whether text is spam
+func (b *Bayes) Train(ctx context.Context, text string, spam bool) error {
+	tokens := documentTokens(text)
+
+	err := b.store.AddClassifierSample(ctx, tokens, spam)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if spam {
+		b.spamDocs++
+	} else {
+		b.hamDocs++
+	}
+	for _, token := range tokens {
+		count := b.tokens[token]
+		if spam {
+			count.spam++
+		} else {
+			count.ham++
+		}
+		b.tokens[token] = count
+	}
+	return nil
+}
+
+func (b *Bayes) Check(ctx context.Context, content Content) (Verdict, error) {
+	score := b.SpamProbability(content.Text)
+	if score == 0 {
+		return Verdict{}, nil
+	}
+	return Verdict{Score: score, Reasons: []string{"looks like spam"}}, nil
+}
+
+// SpamProbability returns the probability of text being spam, or 0 while the
+// classifier has not seen enough of both classes or none of the tokens of text
+func (b *Bayes) SpamProbability(text string) float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.spamDocs < minTrainingDocs || b.hamDocs < minTrainingDocs {
+		return 0
+	}
+
+	// log odds with Laplace smoothing over the presence of every known token.
+	// Both classes get the same prior so a skewed training set does not bias
+	// content the classifier knows nothing about.
+	logOdds, known := 0.0, 0
+	for _, token := range documentTokens(text) {
+		count, ok := b.tokens[token]
+		if !ok {
+			continue
+		}
+		known++
+		pSpam := (float64(count.spam) + 1) / (float64(b.spamDocs) + 2)
+		pHam := (float64(count.ham) + 1) / (float64(b.hamDocs) + 2)
+		logOdds += math.Log(pSpam) - math.Log(pHam)
+	}
+	if known == 0 {
+		return 0
+	}
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+// documentTokens returns the distinct tokens of text
+func documentTokens(text string) []string {
+	seen := map[string]bool{}
+	tokens := []string{}
+	for _, token := range search.Tokenize(text) {
+		if len(token) > maxTokenLength || seen[token] {
+			continue
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+type memoryStore struct {
+	state   model.ClassifierState
+	samples int
+}
+
+func (s *memoryStore) GetClassifierState(ctx context.Context) (model.ClassifierState, error) {
+	return s.state, nil
+}
+
+func (s *memoryStore) AddClassifierSample(ctx context.Context, tokens []string, spam bool) error {
+	s.samples++
+	return nil
+}
+
+func TestBayes(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	bayes := NewBayes(store)
+
+	spam := []string{"cheap pills buy now", "buy cheap watches now", "win money now", "cheap money fast", "buy pills online"}
+	ham := []string{"great article thanks", "i disagree with the second point", "thanks for sharing this", "the article helped me", "nice point about testing"}
+
+	// not enough training yet
+	assert.NoError(t, bayes.Train(ctx, spam[0], true))
+	assert.Zero(t, bayes.SpamProbability("cheap pills"))
+
+	for _, text := range spam[1:] {
+		assert.NoError(t, bayes.Train(ctx, text, true))
+	}
+	for _, text := range ham {
+		assert.NoError(t, bayes.Train(ctx, text, false))
+	}
+	assert.Equal(t, len(spam)+len(ham), store.samples)
+
+	assert.Greater(t, bayes.SpamProbability("buy cheap pills now"), 0.9)
+	assert.Less(t, bayes.SpamProbability("thanks for the article"), 0.1)
+	assert.Zero(t, bayes.SpamProbability("completely unseen words"))
+
+	verdict, err := bayes.Check(ctx, Content{Text: "buy cheap pills now"})
+	assert.NoError(t, err)
+	assert.Greater(t, verdict.Score, 0.9)
+}
+
+func TestBayesLoad(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{state: model.ClassifierState{
+		SpamDocs: 5,
+		HamDocs:  5,
+		Tokens: []model.ClassifierToken{
+			{Token: "casino", SpamCount: 5},
+			{Token: "article", HamCount: 5},
+		},
+	}}
+	bayes := NewBayes(store)
+
+	assert.NoError(t, bayes.Load(ctx))
+	assert.Greater(t, bayes.SpamProbability("casino"), 0.8)
+	assert.Less(t, bayes.SpamProbability("article"), 0.2)
+}
@@ -0,0 +1,46 @@
+package filter
+
+import (
+	"context"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// RecentContentFinder lists the texts the user submitted since the given time,
+// leaving out the post or comment with the excluded id
+type RecentContentFinder interface {
+	GetRecentContent(ctx context.Context, userID int64, kind model.ContentKind, since time.Time, excludeID int64) (texts []string, err error)
+}
+
+// Duplicate scores content the user already submitted within the window,
+// ignoring differences in case and whitespace. Edited content is not compared
+// with its own previous text.
+type Duplicate struct {
+	finder RecentContentFinder
+	window time.Duration
+	score  float64
+}
+
+func NewDuplicate(finder RecentContentFinder, window time.Duration, score float64) *Duplicate {
+	return &Duplicate{finder: finder, window: window, score: score}
+}
+
+func (f *Duplicate) Check(ctx context.Context, content Content) (Verdict, error) {
+	text := normalizeText(content.Text)
+	if text == "" {
+		return Verdict{}, nil
+	}
+
+	recent, err := f.finder.GetRecentContent(ctx, content.UserID, content.Kind, time.Now().Add(-f.window), content.ID)
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	for _, previous := range recent {
+		if normalizeText(previous) == text {
+			return Verdict{Score: f.score, Reasons: []string{"duplicate of a recent " + string(content.Kind)}}, nil
+		}
+	}
+	return Verdict{}, nil
+}
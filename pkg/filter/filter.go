@@ -0,0 +1,87 @@
+package filter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// Content is the text a user submits as a post or a comment. ID is the post or
+// comment being edited, zero for new content.
+type Content struct {
+	ID     int64
+	UserID int64
+	Kind   model.ContentKind
+	Text   string
+}
+
+// Verdict scores how likely content is unwanted, from 0 (clean) to 1, with the
+// reasons given by the filters that matched
+type Verdict struct {
+	Score   float64
+	Reasons []string
+}
+
+type ContentFilter interface {
+	Check(ctx context.Context, content Content) (Verdict, error)
+}
+
+// Chain runs every filter and keeps the highest score
+type Chain []ContentFilter
+
+func (c Chain) Check(ctx context.Context, content Content) (verdict Verdict, err error) {
+	for _, filter := range c {
+		v, err := filter.Check(ctx, content)
+		if err != nil {
+			return Verdict{}, err
+		}
+		verdict.Score = max(verdict.Score, v.Score)
+		verdict.Reasons = append(verdict.Reasons, v.Reasons...)
+	}
+	return verdict, nil
+}
+
+type Action string
+
+const (
+	ActionAllow    Action = "allow"
+	ActionModerate Action = "moderate"
+	ActionReject   Action = "reject"
+)
+
+// Policy decides what happens to content from the score of its filter. Content
+// scoring at least RejectScore is rejected, at least ModerateScore is held for
+// moderation. Content no filter matched, and everything when there is no
+// filter, is allowed.
+type Policy struct {
+	Filter        ContentFilter
+	ModerateScore float64
+	RejectScore   float64
+}
+
+func (p Policy) Evaluate(ctx context.Context, content Content) (Action, Verdict, error) {
+	if p.Filter == nil {
+		return ActionAllow, Verdict{}, nil
+	}
+
+	verdict, err := p.Filter.Check(ctx, content)
+	if err != nil {
+		return ActionAllow, verdict, err
+	}
+
+	switch {
+	case verdict.Score == 0:
+		return ActionAllow, verdict, nil
+	case verdict.Score >= p.RejectScore:
+		return ActionReject, verdict, nil
+	case verdict.Score >= p.ModerateScore:
+		return ActionModerate, verdict, nil
+	}
+	return ActionAllow, verdict, nil
+}
+
+// normalizeText collapses whitespace and case so trivially altered copies compare equal
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
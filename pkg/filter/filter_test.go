@@ -0,0 +1,114 @@
+package filter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+type fixedFilter struct {
+	verdict Verdict
+	err     error
+}
+
+func (f fixedFilter) Check(ctx context.Context, content Content) (Verdict, error) {
+	return f.verdict, f.err
+}
+
+type recentContent []string
+
+func (r recentContent) GetRecentContent(ctx context.Context, userID int64, kind model.ContentKind, since time.Time, excludeID int64) ([]string, error) {
+	return r, nil
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+
+	chain := Chain{
+		fixedFilter{verdict: Verdict{Score: 0.3, Reasons: []string{"a"}}},
+		fixedFilter{},
+		fixedFilter{verdict: Verdict{Score: 0.7, Reasons: []string{"b"}}},
+	}
+
+	verdict, err := chain.Check(ctx, Content{Text: "text"})
+	assert.NoError(t, err)
+	assert.Equal(t, Verdict{Score: 0.7, Reasons: []string{"a", "b"}}, verdict)
+
+	_, err = Chain{fixedFilter{err: assert.AnError}}.Check(ctx, Content{Text: "text"})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   Action
+	}{
+		{"no filter", Policy{}, ActionAllow},
+		{"clean content", Policy{Filter: fixedFilter{}}, ActionAllow},
+		{"below moderation", Policy{Filter: fixedFilter{verdict: Verdict{Score: 0.4}}, ModerateScore: 0.5, RejectScore: 0.9}, ActionAllow},
+		{"moderated", Policy{Filter: fixedFilter{verdict: Verdict{Score: 0.5}}, ModerateScore: 0.5, RejectScore: 0.9}, ActionModerate},
+		{"rejected", Policy{Filter: fixedFilter{verdict: Verdict{Score: 0.95}}, ModerateScore: 0.5, RejectScore: 0.9}, ActionReject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, _, err := tt.policy.Evaluate(ctx, Content{Text: "text"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, action)
+		})
+	}
+}
+
+func TestBannedWords(t *testing.T) {
+	ctx := context.Background()
+	filter := NewBannedWords([]string{" Casino ", "viagra", ""}, 0.5)
+
+	verdict, err := filter.Check(ctx, Content{Text: "A friendly comment"})
+	assert.NoError(t, err)
+	assert.Zero(t, verdict.Score)
+
+	verdict, err = filter.Check(ctx, Content{Text: "Best CASINO in town, casino!"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, verdict.Score)
+
+	verdict, err = filter.Check(ctx, Content{Text: "casino and viagra and more"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, verdict.Score)
+}
+
+func TestLinkLimit(t *testing.T) {
+	ctx := context.Background()
+	filter := LinkLimit{Max: 1}
+
+	verdict, err := filter.Check(ctx, Content{Text: "see https://example.com"})
+	assert.NoError(t, err)
+	assert.Zero(t, verdict.Score)
+
+	verdict, err = filter.Check(ctx, Content{Text: "see https://example.com and www.example.org"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, verdict.Score)
+
+	verdict, err = filter.Check(ctx, Content{Text: "http://a.example https://b.example HTTP://c.example"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, verdict.Score)
+}
+
+func TestDuplicate(t *testing.T) {
+	ctx := context.Background()
+	filter := NewDuplicate(recentContent{"Buy   NOW", "something else"}, time.Hour, 1)
+
+	verdict, err := filter.Check(ctx, Content{UserID: 1, Kind: model.ContentKindComment, Text: "buy now"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, verdict.Score)
+	assert.Equal(t, []string{"duplicate of a recent comment"}, verdict.Reasons)
+
+	verdict, err = filter.Check(ctx, Content{UserID: 1, Kind: model.ContentKindComment, Text: "a new thought"})
+	assert.NoError(t, err)
+	assert.Zero(t, verdict.Score)
+}
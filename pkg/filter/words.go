@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/suhriar/blog-mono-api/pkg/search"
+)
+
+// BannedWords scores content by the number of distinct banned words it
+// contains, each one adding Weight
+type BannedWords struct {
+	words  map[string]bool
+	weight float64
+}
+
+func NewBannedWords(words []string, weight float64) *BannedWords {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			set[word] = true
+		}
+	}
+	return &BannedWords{words: set, weight: weight}
+}
+
+func (f *BannedWords) Check(ctx context.Context, content Content) (Verdict, error) {
+	found := map[string]bool{}
+	for _, token := range search.Tokenize(content.Text) {
+		if f.words[token] {
+			found[token] = true
+		}
+	}
+
+	if len(found) == 0 {
+		return Verdict{}, nil
+	}
+	return Verdict{
+		Score:   math.Min(1, float64(len(found))*f.weight),
+		Reasons: []string{fmt.Sprintf("contains %d banned words", len(found))},
+	}, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)`)
+
+// LinkLimit flags content with more than Max links, content with more than
+// twice as many is scored as certainly unwanted
+type LinkLimit struct {
+	Max int
+}
+
+func (f LinkLimit) Check(ctx context.Context, content Content) (Verdict, error) {
+	links := len(linkPattern.FindAllStringIndex(content.Text, -1))
+	if links <= f.Max {
+		return Verdict{}, nil
+	}
+
+	score := 0.5
+	if links > 2*f.Max {
+		score = 1
+	}
+	return Verdict{
+		Score:   score,
+		Reasons: []string{fmt.Sprintf("contains %d links, at most %d allowed", links, f.Max)},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File appends the messages to a file instead of sending them, so links in
+// them can be followed during local development
+type File struct {
+	path string
+	from string
+
+	mu sync.Mutex
+}
+
+func NewFile(path, from string) *File {
+	return &File{path: path, from: from}
+}
+
+func (m *File) Send(ctx context.Context, msg Message) (err error) {
+	body, err := format(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(body, "\r\n"...))
+	return err
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Success Format", func(t *testing.T) {
+		body, err := format("blog@example.com", Message{To: "alice@example.com", Subject: "Hello", Body: "line 1\nline 2"}, date)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "From: blog@example.com\r\n"+
+			"To: alice@example.com\r\n"+
+			"Subject: Hello\r\n"+
+			"Date: Tue, 02 Jan 2024 03:04:05 +0000\r\n"+
+			"MIME-Version: 1.0\r\n"+
+			"Content-Type: text/plain; charset=UTF-8\r\n"+
+			"\r\n"+
+			"line 1\r\nline 2\r\n", string(body))
+	})
+
+	t.Run("Fail Format - Header Injection", func(t *testing.T) {
+		_, err := format("blog@example.com", Message{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "Hello"}, date)
+
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	msg := Message{To: "alice@example.com", Subject: "Hello", Body: "Hi"}
+
+	assert.NoError(t, m.Send(context.Background(), msg))
+	assert.Equal(t, []Message{msg}, m.Messages())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail", "outbox.log")
+	m := NewFile(path, "blog@example.com")
+
+	assert.NoError(t, m.Send(context.Background(), Message{To: "alice@example.com", Subject: "First", Body: "one"}))
+	assert.NoError(t, m.Send(context.Background(), Message{To: "bob@example.com", Subject: "Second", Body: "two"}))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Subject: First")
+	assert.Contains(t, string(content), "Subject: Second")
+}
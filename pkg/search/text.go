@@ -0,0 +1,127 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+	ellipsis       = "…"
+)
+
+type span struct {
+	start, end int
+	term       string
+}
+
+// Tokenize splits text into lowercase terms made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+// Highlight HTML escapes text and wraps every word matching one of the terms
+// in <mark> tags.
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	return highlight(runes, spans(runes), termSet(terms), 0, len(runes))
+}
+
+// Snippet returns at most maxRunes runes of text around the first word matching
+// one of the terms, with whitespace collapsed and matches highlighted. When no
+// word matches, the beginning of the text is returned.
+func Snippet(text string, terms []string, maxRunes int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	words := spans(runes)
+	set := termSet(terms)
+
+	start := 0
+	for _, word := range words {
+		if set[word.term] {
+			// keep a little context before the first match
+			start = word.start - maxRunes/4
+			break
+		}
+	}
+	end := start + maxRunes
+	if end > len(runes) {
+		end = len(runes)
+		start = end - maxRunes
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	// do not cut a word in half at either end of the window
+	for _, word := range words {
+		if start > 0 && word.start < start && word.end > start {
+			start = word.end
+		}
+		if end < len(runes) && word.start < end && word.end > end && word.start > start {
+			end = word.start
+		}
+	}
+
+	snippet := highlight(runes, words, set, start, end)
+	if start > 0 {
+		snippet = ellipsis + strings.TrimLeft(snippet, " ")
+	}
+	if end < len(runes) {
+		snippet = strings.TrimRight(snippet, " ") + ellipsis
+	}
+	return snippet
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func termSet(terms []string) map[string]bool {
+	set := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		set[strings.ToLower(term)] = true
+	}
+	return set
+}
+
+// spans returns the position of every word in runes
+func spans(runes []rune) []span {
+	words := []span{}
+	start := -1
+	for i, r := range runes {
+		switch {
+		case !isSeparator(r) && start < 0:
+			start = i
+		case isSeparator(r) && start >= 0:
+			words = append(words, span{start: start, end: i, term: strings.ToLower(string(runes[start:i]))})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, span{start: start, end: len(runes), term: strings.ToLower(string(runes[start:]))})
+	}
+	return words
+}
+
+// highlight escapes runes[start:end], marking the words whose term is in set
+func highlight(runes []rune, words []span, set map[string]bool, start, end int) string {
+	var b strings.Builder
+	pos := start
+	for _, word := range words {
+		if word.end > end {
+			break
+		}
+		if word.start < start || !set[word.term] {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:word.start])))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(string(runes[word.start:word.end])))
+		b.WriteString(highlightClose)
+		pos = word.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	return b.String()
+}
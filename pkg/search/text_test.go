@@ -0,0 +1,42 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"go", "1", "23", "is", "fast"}, Tokenize("Go 1.23 is fast!"))
+	assert.Empty(t, Tokenize("  --- "))
+}
+
+func TestHighlight(t *testing.T) {
+	highlighted := Highlight("Learning Go & going <fast>", []string{"GO", "fast"})
+
+	assert.Equal(t, "Learning <mark>Go</mark> &amp; going &lt;<mark>fast</mark>&gt;", highlighted)
+}
+
+func TestSnippet(t *testing.T) {
+	t.Run("Match In The Middle", func(t *testing.T) {
+		text := strings.Repeat("lorem ", 50) + "golang\nrocks " + strings.Repeat("ipsum ", 50)
+
+		snippet := Snippet(text, []string{"golang"}, 40)
+
+		assert.True(t, strings.HasPrefix(snippet, ellipsis))
+		assert.True(t, strings.HasSuffix(snippet, ellipsis))
+		assert.Contains(t, snippet, "<mark>golang</mark> rocks")
+		assert.NotContains(t, snippet, ellipsis+" ")
+		assert.NotContains(t, snippet, " "+ellipsis)
+		assert.True(t, strings.HasSuffix(snippet, "ipsum"+ellipsis) || strings.HasSuffix(snippet, "rocks"+ellipsis))
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		assert.Equal(t, "short &lt;b&gt; text", Snippet("short <b>   text", []string{"missing"}, 40))
+	})
+
+	t.Run("Truncated Without Match", func(t *testing.T) {
+		assert.Equal(t, "one two"+ellipsis, Snippet("one two three", []string{"missing"}, 9))
+	})
+}
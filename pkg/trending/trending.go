@@ -0,0 +1,94 @@
+// Package trending ranks posts and hashtags by their recent engagement. Every
+// interaction adds its weight to the score of the post, halved for every
+// half-life elapsed since it happened.
+package trending
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// Weights is the score a single interaction of each kind adds when it is new,
+// kinds without a weight are ignored
+type Weights map[model.EngagementKind]float64
+
+// Score sums the decayed weights of the engagements per post. Engagements in
+// the future count as happening now.
+func Score(engagements []model.Engagement, weights Weights, halfLife time.Duration, now time.Time) map[int64]float64 {
+	scores := map[int64]float64{}
+	for _, engagement := range engagements {
+		weight := weights[engagement.Kind]
+		if weight == 0 || engagement.Count <= 0 {
+			continue
+		}
+
+		age := now.Sub(engagement.Hour)
+		if age < 0 {
+			age = 0
+		}
+		scores[engagement.PostID] += weight * float64(engagement.Count) * decay(age, halfLife)
+	}
+	return scores
+}
+
+func decay(age, halfLife time.Duration) float64 {
+	if halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// TopPosts returns the limit best scored posts, ties broken by the newer post id
+func TopPosts(scores map[int64]float64, limit int) []model.TrendingPost {
+	posts := make([]model.TrendingPost, 0, len(scores))
+	for postID, score := range scores {
+		posts = append(posts, model.TrendingPost{PostID: postID, Score: score})
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].Score != posts[j].Score {
+			return posts[i].Score > posts[j].Score
+		}
+		return posts[i].PostID > posts[j].PostID
+	})
+
+	if limit >= 0 && len(posts) > limit {
+		posts = posts[:limit]
+	}
+	return posts
+}
+
+// TopTags scores every hashtag with the sum of the scores of its posts and
+// returns the limit best scored ones, ties broken by name
+func TopTags(scores map[int64]float64, postTags map[int64][]string, limit int) []model.TrendingTag {
+	byName := map[string]*model.TrendingTag{}
+	for postID, score := range scores {
+		for _, name := range postTags[postID] {
+			tag, ok := byName[name]
+			if !ok {
+				tag = &model.TrendingTag{Name: name}
+				byName[name] = tag
+			}
+			tag.Score += score
+			tag.PostCount++
+		}
+	}
+
+	tags := make([]model.TrendingTag, 0, len(byName))
+	for _, tag := range byName {
+		tags = append(tags, *tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Score != tags[j].Score {
+			return tags[i].Score > tags[j].Score
+		}
+		return tags[i].Name < tags[j].Name
+	})
+
+	if limit >= 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags
+}
@@ -0,0 +1,47 @@
+package trending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+func TestScore(t *testing.T) {
+	now := time.Now()
+	weights := Weights{model.EngagementLike: 2, model.EngagementComment: 4, model.EngagementView: 0.5}
+
+	scores := Score([]model.Engagement{
+		{PostID: 1, Kind: model.EngagementLike, Hour: now, Count: 3},
+		{PostID: 1, Kind: model.EngagementComment, Hour: now.Add(-24 * time.Hour), Count: 1},
+		{PostID: 2, Kind: model.EngagementView, Hour: now.Add(-48 * time.Hour), Count: 8},
+		{PostID: 2, Kind: model.EngagementLike, Hour: now.Add(time.Hour), Count: 1},
+		{PostID: 3, Kind: "unknown", Hour: now, Count: 100},
+	}, weights, 24*time.Hour, now)
+
+	assert.InDelta(t, 2*3+4*0.5, scores[1], 1e-9)
+	assert.InDelta(t, 0.5*8*0.25+2, scores[2], 1e-9)
+	assert.NotContains(t, scores, int64(3))
+}
+
+func TestTopPosts(t *testing.T) {
+	scores := map[int64]float64{1: 5, 2: 9, 3: 5, 4: 1}
+
+	assert.Equal(t, []model.TrendingPost{
+		{PostID: 2, Score: 9},
+		{PostID: 3, Score: 5},
+		{PostID: 1, Score: 5},
+	}, TopPosts(scores, 3))
+	assert.Len(t, TopPosts(scores, 10), 4)
+}
+
+func TestTopTags(t *testing.T) {
+	scores := map[int64]float64{1: 5, 2: 3, 3: 1}
+	postTags := map[int64][]string{1: {"go"}, 2: {"go", "sql"}, 3: {"sql", "web"}}
+
+	assert.Equal(t, []model.TrendingTag{
+		{Name: "go", Score: 8, PostCount: 2},
+		{Name: "sql", Score: 4, PostCount: 2},
+	}, TopTags(scores, postTags, 2))
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/suhriar/blog-mono-api/model"
+)
+
+// EncodeCursor returns the opaque representation of the cursor handed to clients
+func EncodeCursor(cursor model.Cursor) string {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor
+func DecodeCursor(value string) (cursor model.Cursor, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(b, &cursor)
+	return
+}
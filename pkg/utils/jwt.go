@@ -9,11 +9,12 @@ import (
 	"github.com/suhriar/blog-mono-api/model"
 )
 
-func GenerateJWT(userID int64, email, username string) (tokenString string, err error) {
+func GenerateJWT(userID int64, email, username string, emailVerified bool) (tokenString string, err error) {
 	claims := &model.JwtCustomClaims{
-		UserID:   userID,
-		Username: username,
-		Email:    email,
+		UserID:        userID,
+		Username:      username,
+		Email:         email,
+		EmailVerified: emailVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
 		},
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 )
 
@@ -13,3 +14,21 @@ func GenerateRefreshToken() string {
 	}
 	return hex.EncodeToString(b)
 }
+
+// GenerateToken returns a random 256 bit token, or an empty string when the
+// random source fails
+func GenerateToken() string {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the token, for storing
+// tokens that must not be readable from the database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
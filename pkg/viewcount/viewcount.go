@@ -0,0 +1,98 @@
+// Package viewcount buffers post views in memory and writes them to a store in
+// batches. Repeated views of the same viewer within a window count once.
+package viewcount
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store persists the buffered views, counts maps post ids to the number of
+// views to add and at is when they are flushed
+type Store interface {
+	IncrementViewCounts(ctx context.Context, counts map[int64]int64, at time.Time) (err error)
+}
+
+type viewKey struct {
+	postID int64
+	viewer string
+}
+
+// Counter deduplicates and buffers views until Flush writes them to the store.
+// It is safe for concurrent use.
+type Counter struct {
+	store  Store
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	seen    map[viewKey]time.Time
+	pending map[int64]int64
+}
+
+func NewCounter(store Store, window time.Duration) *Counter {
+	return &Counter{
+		store:   store,
+		window:  window,
+		now:     time.Now,
+		seen:    map[viewKey]time.Time{},
+		pending: map[int64]int64{},
+	}
+}
+
+// Record counts a view of the post unless the viewer key already viewed it
+// within the window, views without a viewer key are always counted. It reports
+// whether the view was counted.
+func (c *Counter) Record(postID int64, viewer string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if viewer != "" {
+		key := viewKey{postID: postID, viewer: viewer}
+		if last, ok := c.seen[key]; ok && now.Sub(last) < c.window {
+			return false
+		}
+		c.seen[key] = now
+	}
+	c.pending[postID]++
+	return true
+}
+
+// Pending returns the views of the post not written to the store yet
+func (c *Counter) Pending(postID int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pending[postID]
+}
+
+// Flush writes the buffered views to the store and forgets viewers outside the
+// window. Views failing to be written are kept for the next flush.
+func (c *Counter) Flush(ctx context.Context) (err error) {
+	c.mu.Lock()
+	counts := c.pending
+	c.pending = map[int64]int64{}
+
+	now := c.now()
+	for key, last := range c.seen {
+		if now.Sub(last) >= c.window {
+			delete(c.seen, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	if err = c.store.IncrementViewCounts(ctx, counts, now); err != nil {
+		c.mu.Lock()
+		for postID, count := range counts {
+			c.pending[postID] += count
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package viewcount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingStore struct {
+	flushed []map[int64]int64
+	err     error
+}
+
+func (s *recordingStore) IncrementViewCounts(ctx context.Context, counts map[int64]int64, at time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.flushed = append(s.flushed, counts)
+	return nil
+}
+
+func newTestCounter(store Store, now *time.Time) *Counter {
+	counter := NewCounter(store, 30*time.Minute)
+	counter.now = func() time.Time { return *now }
+	return counter
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Now()
+	counter := newTestCounter(&recordingStore{}, &now)
+
+	assert.True(t, counter.Record(1, "user:1"))
+	assert.False(t, counter.Record(1, "user:1"), "same viewer within the window")
+	assert.True(t, counter.Record(1, "user:2"), "other viewer")
+	assert.True(t, counter.Record(2, "user:1"), "other post")
+	assert.True(t, counter.Record(1, ""))
+	assert.True(t, counter.Record(1, ""), "views without a viewer are not deduplicated")
+	assert.Equal(t, int64(4), counter.Pending(1))
+
+	now = now.Add(30 * time.Minute)
+	assert.True(t, counter.Record(1, "user:1"), "window elapsed")
+	assert.Equal(t, int64(5), counter.Pending(1))
+}
+
+func TestFlush(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Success Flush", func(t *testing.T) {
+		store := &recordingStore{}
+		counter := newTestCounter(store, &now)
+
+		counter.Record(1, "user:1")
+		counter.Record(1, "user:2")
+		counter.Record(2, "user:1")
+
+		assert.NoError(t, counter.Flush(ctx))
+		assert.Equal(t, []map[int64]int64{{1: 2, 2: 1}}, store.flushed)
+		assert.Equal(t, int64(0), counter.Pending(1))
+
+		assert.NoError(t, counter.Flush(ctx))
+		assert.Len(t, store.flushed, 1, "nothing to flush")
+	})
+
+	t.Run("Success Flush - Forgets Expired Viewers", func(t *testing.T) {
+		counter := newTestCounter(&recordingStore{}, &now)
+
+		counter.Record(1, "user:1")
+		now = now.Add(time.Hour)
+		assert.NoError(t, counter.Flush(ctx))
+		assert.Empty(t, counter.seen)
+	})
+
+	t.Run("Fail Flush - Keeps Views", func(t *testing.T) {
+		store := &recordingStore{err: assert.AnError}
+		counter := newTestCounter(store, &now)
+
+		counter.Record(1, "user:1")
+		assert.ErrorIs(t, counter.Flush(ctx), assert.AnError)
+		counter.Record(1, "user:2")
+		assert.Equal(t, int64(2), counter.Pending(1))
+	})
+}